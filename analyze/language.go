@@ -0,0 +1,323 @@
+package analyze
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LanguageRequirement is one human language a posting asks for
+type LanguageRequirement struct {
+	Language string `json:"language"`        // ISO 639-1 code, e.g. "de"
+	Level    string `json:"level,omitempty"` // CEFR level (A1-C2) when the posting states one
+	Required bool   `json:"required"`        // false when the language is only "a plus"
+	Evidence string `json:"evidence"`
+}
+
+// LanguageResult lists the language requirements of a posting and the language it is written in
+type LanguageResult struct {
+	PostingLanguage string                `json:"posting_language,omitempty"` // "" when it cannot be told
+	Requirements    []LanguageRequirement `json:"requirements,omitempty"`
+}
+
+// Requires reports whether the posting requires the given language (not just "a plus")
+func (r LanguageResult) Requires(code string) bool {
+	for _, req := range r.Requirements {
+		if req.Required && req.Language == code {
+			return true
+		}
+	}
+	return false
+}
+
+// languageNames maps language names in English, German and Dutch to ISO 639-1 codes
+var languageNames = map[string]string{
+	"english": "en", "englisch": "en", "engels": "en",
+	"german": "de", "deutsch": "de", "duits": "de",
+	"dutch": "nl", "niederländisch": "nl", "nederlands": "nl", "flemish": "nl",
+	"french": "fr", "französisch": "fr", "frans": "fr",
+	"spanish": "es", "spanisch": "es", "spaans": "es",
+	"italian": "it", "italienisch": "it", "italiaans": "it",
+	"polish": "pl", "polnisch": "pl", "pools": "pl",
+	"portuguese": "pt", "portugiesisch": "pt", "portugees": "pt",
+	"indonesian": "id", "indonesisch": "id", "bahasa": "id",
+	"mandarin": "zh", "chinese": "zh", "chinesisch": "zh",
+	"japanese": "ja", "japanisch": "ja", "japans": "ja",
+}
+
+// LanguageDisplayNames gives the English name for the codes in languageNames
+var LanguageDisplayNames = map[string]string{
+	"en": "English", "de": "German", "nl": "Dutch", "fr": "French", "es": "Spanish",
+	"it": "Italian", "pl": "Polish", "pt": "Portuguese", "id": "Indonesian",
+	"zh": "Chinese", "ja": "Japanese",
+}
+
+// languageSuffixes are the endings a language name may carry in German and Dutch
+// compounds and adjectives ("Deutschkenntnisse", "deutschsprachig", "Nederlandstalige")
+var languageSuffixes = []string{"", "e", "en", "kenntnisse", "kenntnissen", "sprachig", "sprachige", "sprachigen", "talig", "talige"}
+
+// skillWords show that a clause talks about language skills rather than, say, a German company
+var skillWords = map[string]bool{
+	// English
+	"fluent": true, "fluency": true, "fluently": true, "native": true, "proficient": true,
+	"proficiency": true, "speak": true, "speaking": true, "spoken": true, "written": true,
+	"skills": true, "knowledge": true, "command": true, "level": true, "communication": true,
+	"language": true, "languages": true, "speaker": true, "mother": true,
+	// German
+	"fließend": true, "fliessend": true, "verhandlungssicher": true, "sprachkenntnisse": true,
+	"kenntnisse": true, "muttersprache": true, "muttersprachlich": true, "sprache": true,
+	"wort": true, "schrift": true, "sprechen": true, "sprichst": true, "sprachniveau": true,
+	// Dutch
+	"vloeiend": true, "beheersing": true, "kennis": true, "spreek": true, "spreekt": true,
+	"taal": true, "talen": true, "moedertaal": true, "schriftelijk": true, "mondeling": true,
+}
+
+// optionalPhrases turn a language requirement into a nice-to-have
+var optionalPhrases = []string{
+	// English
+	"plus", "bonus", "nice to have", "nice-to-have", "advantage", "advantageous", "preferred",
+	"desirable", "beneficial", "ideally", "helpful", "asset",
+	// German
+	"wünschenswert", "von vorteil", "vorteilhaft", "ein plus", "gerne", "idealerweise",
+	// Dutch
+	"pré", "pluspunt", "voordeel", "wenselijk", "gewenst", "mooi meegenomen",
+}
+
+// notRequiredPhrases say a language is explicitly not needed
+var notRequiredPhrases = []string{
+	"not required", "not necessary", "not needed", "no need", "not a requirement",
+	"nicht erforderlich", "nicht notwendig", "nicht nötig", "keine voraussetzung",
+	"niet vereist", "niet nodig", "geen vereiste", "geen vereist",
+}
+
+// cefrPattern matches a CEFR level such as B2 or c1
+var cefrPattern = regexp.MustCompile(`^[abc][12]$`)
+
+// stopwords are frequent short words used to tell the language of a posting
+var stopwords = map[string]map[string]bool{
+	"en": setOf("the", "and", "with", "you", "we", "our", "for", "of", "to", "are", "will", "your", "a", "an"),
+	"de": setOf("und", "der", "die", "das", "mit", "wir", "du", "sie", "für", "ist", "sind", "ein", "eine", "den", "dem", "zu", "von", "auf", "bei", "dein", "unsere"),
+	"nl": setOf("en", "het", "een", "van", "met", "wij", "je", "jij", "voor", "zijn", "op", "bij", "ons", "onze", "naar", "ben", "jouw"),
+}
+
+// setOf builds a lookup set from words
+func setOf(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// languageMention is a language name found at a token position
+type languageMention struct {
+	code string
+	at   int
+}
+
+// Languages finds the human-language skills a job description asks for, with CEFR
+// levels where stated, and detects the language the description is written in
+func Languages(description string) LanguageResult {
+	result := LanguageResult{PostingLanguage: PostingLanguage(description)}
+	seen := make(map[string]int) // language code -> index in Requirements
+
+	for _, sentence := range sentences(description) {
+		for _, clause := range clauses(sentence) {
+			for _, req := range languageClause(clause, sentence) {
+				i, ok := seen[req.Language]
+				if !ok {
+					seen[req.Language] = len(result.Requirements)
+					result.Requirements = append(result.Requirements, req)
+					continue
+				}
+				// A later, stricter mention of the same language wins
+				existing := &result.Requirements[i]
+				if req.Required && !existing.Required {
+					*existing = req
+				} else if existing.Level == "" && req.Level != "" && req.Required == existing.Required {
+					existing.Level = req.Level
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// languageClause returns the requirements stated in one clause
+func languageClause(clause, sentence string) []LanguageRequirement {
+	tokens := words(clause)
+	lower := strings.ToLower(clause)
+
+	var mentions []languageMention
+	hasSkillWord := false
+	for i, token := range tokens {
+		if code, compound := languageOf(token); code != "" {
+			mentions = append(mentions, languageMention{code: code, at: i})
+			if compound {
+				hasSkillWord = true
+			}
+		}
+		if skillWords[strings.SplitN(token, "-", 2)[0]] || cefrPattern.MatchString(token) {
+			hasSkillWord = true
+		}
+	}
+	if len(mentions) == 0 || !hasSkillWord {
+		return nil
+	}
+
+	required := !containsPhrase(lower, tokens, optionalPhrases)
+
+	var reqs []LanguageRequirement
+	for m, mention := range mentions {
+		if languageNegated(tokens, mentions, m) {
+			continue // "no German needed", "keine Deutschkenntnisse", "German is not required"
+		}
+		reqs = append(reqs, LanguageRequirement{
+			Language: mention.code,
+			Level:    levelFor(tokens, mentions, m),
+			Required: required,
+			Evidence: snippet(sentence, 200),
+		})
+	}
+	return reqs
+}
+
+// languageOf returns the language code a token names, and whether it was a compound
+// such as "Deutschkenntnisse" that carries the skill meaning by itself
+func languageOf(token string) (string, bool) {
+	token = strings.SplitN(token, "-", 2)[0]
+	if code, ok := languageNames[token]; ok {
+		return code, false
+	}
+	for name, code := range languageNames {
+		if !strings.HasPrefix(token, name) {
+			continue
+		}
+		for _, suffix := range languageSuffixes[1:] {
+			if token == name+suffix {
+				return code, suffix != "e" && suffix != "en"
+			}
+		}
+	}
+	return "", false
+}
+
+// languageNegated reports whether a negation governs mentions[m] directly: a negation right
+// before it ("no German", "geen vloeiend Nederlands") or a not-required phrase right around
+// it ("German is not required", "no need for German"). A negation elsewhere in the clause
+// is about something else, as in "you don't need a degree but fluent German is required".
+func languageNegated(tokens []string, mentions []languageMention, m int) bool {
+	at := mentions[m].at
+	if at > 0 && negations[tokens[at-1]] {
+		return true
+	}
+	if at > 1 && negations[tokens[at-2]] && skillWords[tokens[at-1]] {
+		return true
+	}
+
+	end := len(tokens)
+	if m+1 < len(mentions) {
+		end = mentions[m+1].at
+	}
+	for _, phrase := range notRequiredPhrases {
+		phraseTokens := words(phrase)
+		for i := at + 1; i < end && i <= at+3; i++ {
+			if findPhrase(tokens[i:], phraseTokens) == 0 {
+				return true
+			}
+		}
+		for i := at - len(phraseTokens) - 1; i < at-len(phraseTokens)+1; i++ {
+			if i >= 0 && findPhrase(tokens[i:at], phraseTokens) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// levelFor finds the CEFR level belonging to mentions[m]: the first level after it and
+// before the next language, or for the first language of a clause the closest level
+// before it. A level after another language belongs to that language.
+func levelFor(tokens []string, mentions []languageMention, m int) string {
+	end := len(tokens)
+	if m+1 < len(mentions) {
+		end = mentions[m+1].at
+	}
+	for i := mentions[m].at + 1; i < end; i++ {
+		if cefrPattern.MatchString(tokens[i]) {
+			return strings.ToUpper(tokens[i])
+		}
+	}
+
+	if m > 0 {
+		return ""
+	}
+	for i := mentions[m].at - 1; i >= 0; i-- {
+		if cefrPattern.MatchString(tokens[i]) {
+			return strings.ToUpper(tokens[i])
+		}
+	}
+	return ""
+}
+
+// containsPhrase reports whether any phrase occurs in the clause, matching single words
+// against tokens and longer phrases against the lower-cased text
+func containsPhrase(lower string, tokens []string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if strings.Contains(phrase, " ") {
+			if strings.Contains(lower, phrase) {
+				return true
+			}
+			continue
+		}
+		for _, token := range tokens {
+			if token == phrase {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PostingLanguage guesses whether a description is written in English, German or Dutch
+// by counting frequent short words. It returns "" for short or mixed texts.
+func PostingLanguage(description string) string {
+	counts := make(map[string]int)
+	for _, token := range words(description) {
+		for code, set := range stopwords {
+			if set[token] {
+				counts[code]++
+			}
+		}
+	}
+
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if counts[codes[i]] != counts[codes[j]] {
+			return counts[codes[i]] > counts[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+
+	if len(codes) == 0 || counts[codes[0]] < 3 {
+		return ""
+	}
+	if len(codes) > 1 && counts[codes[0]] < counts[codes[1]]*3/2 {
+		return "" // too close to call
+	}
+	return codes[0]
+}
+
+// LanguageCode resolves an ISO 639-1 code or a language name ("German", "Deutsch") to a code
+func LanguageCode(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if _, ok := LanguageDisplayNames[value]; ok {
+		return value, true
+	}
+	code, ok := languageNames[value]
+	return code, ok
+}
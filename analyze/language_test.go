@@ -0,0 +1,95 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// formatRequirements writes requirements as "de:C1:required en::plus" for comparison
+func formatRequirements(reqs []LanguageRequirement) string {
+	var parts []string
+	for _, req := range reqs {
+		kind := "required"
+		if !req.Required {
+			kind = "plus"
+		}
+		parts = append(parts, fmt.Sprintf("%s:%s:%s", req.Language, req.Level, kind))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestLanguages(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"Fluent German is required.", "de::required"},
+		{"You speak German at C1 level.", "de:C1:required"},
+		{"Sehr gute Deutschkenntnisse (C1) sind Voraussetzung.", "de:C1:required"},
+		{"Fluent English, German skills are a plus.", "en::required de::plus"},
+		{"Deutschkenntnisse sind von Vorteil.", "de::plus"},
+		{"Je spreekt vloeiend Nederlands en Engels.", "nl::required en::required"},
+		{"English (C1) and German (B2) skills", "en:C1:required de:B2:required"},
+		{"Sehr gute Deutschkenntnisse (C1) und gute Englischkenntnisse.", "de:C1:required en::required"},
+		{"C1 English skills required.", "en:C1:required"},
+		{"German is not required.", ""},
+		{"No German needed, fluent English is a must.", "en::required"},
+		{"Keine Deutschkenntnisse erforderlich.", ""},
+		{"Geen vloeiend Nederlands nodig.", ""},
+		{"No need for German skills.", ""},
+		{"You don't need a degree but fluent German is required.", "de::required"},
+		{"We are not a typical company: fluent German is required.", "de::required"},
+		{"A degree is not required but fluent German is.", "de::required"},
+		{"Fluent English is required and German is not required.", "en::required"},
+		{"Good English skills.\nGerman at B2 level is a must.", "en::required de:B2:required"},
+		{"English is a plus.\nFluent English is required.", "en::required"},
+		{"We are a German company with offices in Berlin.", ""},
+		{"We build payment APIs in Go.", ""},
+	}
+
+	for _, tt := range tests {
+		got := Languages(tt.description)
+		if formatted := formatRequirements(got.Requirements); formatted != tt.want {
+			t.Errorf("Languages(%q) = %q, want %q", tt.description, formatted, tt.want)
+		}
+	}
+}
+
+func TestPostingLanguage(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"We are looking for a Go developer to join our team and you will work with the platform.", "en"},
+		{"Wir suchen für unser Team in Berlin eine Entwicklerin und du arbeitest mit der Plattform.", "de"},
+		{"Wij zoeken voor ons team een developer en je werkt met het platform van de klant.", "nl"},
+		{"Go developer", ""},
+	}
+
+	for _, tt := range tests {
+		if got := PostingLanguage(tt.description); got != tt.want {
+			t.Errorf("PostingLanguage(%q) = %q, want %q", tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageCode(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"de", "de", true},
+		{"German", "de", true},
+		{" Deutsch ", "de", true},
+		{"nederlands", "nl", true},
+		{"klingon", "", false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := LanguageCode(tt.value); got != tt.want || ok != tt.ok {
+			t.Errorf("LanguageCode(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
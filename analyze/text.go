@@ -0,0 +1,63 @@
+package analyze
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// sentenceEnd splits description lines into sentences
+	sentenceEnd = regexp.MustCompile(`[.!?;]+\s+|[.!?;]+$`)
+	// wordPattern matches words in any script, keeping apostrophes and hyphens inside words
+	wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’-][\p{L}\p{N}]+)*`)
+)
+
+// sentences splits a description into trimmed sentences, one bullet point or line at most each
+func sentences(text string) []string {
+	var result []string
+	for _, line := range strings.Split(text, "\n") {
+		for _, sentence := range sentenceEnd.Split(line, -1) {
+			if sentence = strings.TrimSpace(sentence); sentence != "" {
+				result = append(result, sentence)
+			}
+		}
+	}
+	return result
+}
+
+// clauses splits a sentence at commas so that a negation in one clause does not leak into the next
+func clauses(sentence string) []string {
+	return strings.Split(sentence, ",")
+}
+
+// words returns the lower-cased words of text
+func words(text string) []string {
+	return wordPattern.FindAllString(strings.ToLower(text), -1)
+}
+
+// findPhrase returns the index of the first occurrence of phrase (a sequence of words)
+// in tokens, or -1
+func findPhrase(tokens, phrase []string) int {
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, word := range phrase {
+			if tokens[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// snippet shortens a sentence to at most max runes for use as evidence
+func snippet(sentence string, max int) string {
+	runes := []rune(sentence)
+	if len(runes) <= max {
+		return sentence
+	}
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
@@ -0,0 +1,264 @@
+package analyze
+
+import "strings"
+
+// VisaStatus tells whether a posting offers visa sponsorship or relocation help
+type VisaStatus string
+
+const (
+	VisaSupported    VisaStatus = "supported"
+	VisaNotSupported VisaStatus = "not_supported"
+	VisaUnknown      VisaStatus = "unknown"
+)
+
+// VisaTopic is what the evidence sentence talks about
+type VisaTopic string
+
+const (
+	TopicSponsorship VisaTopic = "sponsorship"
+	TopicRelocation  VisaTopic = "relocation"
+	TopicWorkPermit  VisaTopic = "work_permit" // work permits and the right to work
+)
+
+// VisaResult is the outcome of Visa together with the sentence it was based on
+type VisaResult struct {
+	Status   VisaStatus `json:"status"`
+	Topic    VisaTopic  `json:"topic,omitempty"`
+	Evidence string     `json:"evidence,omitempty"`
+}
+
+// visaPhrases lists, per topic, the English, German and Dutch phrases that mark a statement.
+// Sponsorship and relocation phrases are offers by themselves; work-permit phrases are
+// requirements unless the clause also says the employer helps with them.
+var visaPhrases = map[VisaTopic][]string{
+	TopicSponsorship: {
+		// English
+		"visa sponsorship", "sponsor visas", "sponsor visa", "sponsor your visa", "sponsor a visa",
+		"sponsor work visas", "visa support", "visa assistance", "immigration support",
+		"recognised sponsor", "recognized sponsor", "blue card", "sponsorship",
+		// German
+		"visasponsoring", "visa-sponsoring", "visumsunterstützung", "visaunterstützung",
+		"visa-unterstützung", "blaue karte",
+		// Dutch
+		"visumsponsoring", "erkend referent", "kennismigrant", "kennismigrantenregeling",
+	},
+	TopicRelocation: {
+		// English
+		"relocation support", "relocation package", "relocation assistance", "relocation bonus",
+		"relocation budget", "help you relocate", "relocation",
+		// German
+		"umzugsunterstützung", "umzugskosten", "umzugspauschale", "relocation-paket",
+		"unterstützung beim umzug",
+		// Dutch
+		"verhuisvergoeding", "verhuiskosten", "hulp bij verhuizing", "relocatie",
+	},
+	TopicWorkPermit: {
+		// English
+		"right to work", "work permit", "work authorization", "work authorisation",
+		"eligible to work", "authorized to work", "authorised to work", "work visa",
+		"residence permit", "eu citizenship", "eu citizen", "eu passport", "visa",
+		// German
+		"arbeitserlaubnis", "arbeitsgenehmigung", "aufenthaltstitel", "aufenthaltserlaubnis",
+		"arbeitsvisum", "staatsbürgerschaft", "visum",
+		// Dutch
+		"werkvergunning", "verblijfsvergunning", "recht om te werken", "werkvisum",
+		"tewerkstellingsvergunning",
+	},
+}
+
+// negations invert a statement when they appear shortly before the phrase in the same clause
+var negations = map[string]bool{
+	// English
+	"no": true, "not": true, "cannot": true, "can't": true, "can’t": true, "unable": true,
+	"without": true, "don't": true, "don’t": true, "doesn't": true, "doesn’t": true,
+	"won't": true, "won’t": true, "never": true, "neither": true, "nor": true, "non": true,
+	// German
+	"kein": true, "keine": true, "keinen": true, "keinem": true, "keiner": true,
+	"nicht": true, "ohne": true,
+	// Dutch
+	"geen": true, "niet": true, "zonder": true,
+}
+
+// negationWindow is how many words before a phrase a negation may stand, so "we don't
+// require a degree and we offer visa sponsorship" is not read as a refusal
+const negationWindow = 6
+
+// contrastWords end the reach of a negation: "we can't sponsor visas but offer relocation"
+var contrastWords = map[string]bool{
+	"but": true, "however": true, "aber": true, "sondern": true, "jedoch": true, "maar": true,
+}
+
+// conjunctions end the reach of a negation too ("no experience needed and visa sponsorship
+// available"), unless they join two visa phrases ("unable to offer relocation and visa sponsorship")
+var conjunctions = map[string]bool{"and": true, "und": true, "en": true}
+
+// phraseEnds are the last words of the visa phrases
+var phraseEnds = func() map[string]bool {
+	ends := make(map[string]bool)
+	for _, phrases := range visaPhrases {
+		for _, phrase := range phrases {
+			tokens := words(phrase)
+			ends[tokens[len(tokens)-1]] = true
+		}
+	}
+	return ends
+}()
+
+// sponsorshipContext makes a bare "sponsorship" about visas rather than, say, sponsoring an event
+var sponsorshipContext = []string{
+	"visa", "visas", "visum", "visa's", "permit", "permits", "immigration", "h1b", "h-1b",
+	"arbeitserlaubnis", "aufenthaltstitel", "werkvergunning", "kennismigrant",
+}
+
+// trailingNegations invert a statement when they follow the phrase closely, as in
+// "Visa sponsern wir nicht" or "we do sponsorship not"
+var trailingNegations = map[string]bool{"not": true, "nicht": true, "niet": true, "keine": true, "geen": true}
+
+// helpWords mark a work-permit clause as an offer of help rather than a requirement
+var helpWords = []string{
+	// English
+	"help", "helps", "support", "supports", "assist", "assists", "assistance", "sponsor",
+	"sponsors", "arrange", "handle", "provide", "provides", "offer", "offers", "offering",
+	// German
+	"unterstützen", "unterstützt", "unterstützung", "helfen", "hilfe", "kümmern", "begleiten",
+	"sponsern", "übernehmen", "bieten", "bietet", "anbieten",
+	// Dutch
+	"helpen", "ondersteunen", "ondersteuning", "hulp", "regelen", "sponsoren", "bieden", "biedt",
+}
+
+// visaFinding is one statement found in a description
+type visaFinding struct {
+	topic    VisaTopic
+	status   VisaStatus
+	evidence string
+}
+
+// Visa scans a job description for visa sponsorship, relocation, work-permit and
+// right-to-work statements. A refusal to sponsor or a right-to-work requirement wins
+// over any offer; otherwise any offer of sponsorship or relocation makes it supported.
+func Visa(description string) VisaResult {
+	var findings []visaFinding
+	for _, sentence := range sentences(description) {
+		for _, clause := range clauses(sentence) {
+			findings = append(findings, visaClause(clause, sentence)...)
+		}
+	}
+
+	// Precedence: a sponsorship or permit refusal, then any offer, then a relocation refusal
+	ranks := []func(visaFinding) bool{
+		func(f visaFinding) bool { return f.status == VisaNotSupported && f.topic != TopicRelocation },
+		func(f visaFinding) bool { return f.status == VisaSupported },
+		func(f visaFinding) bool { return f.status == VisaNotSupported },
+	}
+	for _, rank := range ranks {
+		for _, finding := range findings {
+			if rank(finding) {
+				return VisaResult{Status: finding.status, Topic: finding.topic, Evidence: snippet(finding.evidence, 200)}
+			}
+		}
+	}
+
+	return VisaResult{Status: VisaUnknown}
+}
+
+// visaClause returns at most one finding per topic for one clause. Words matched by a
+// sponsorship or relocation phrase are not matched again as a work permit, so the "visa"
+// in "visa sponsorship" is not taken for a visa requirement.
+func visaClause(clause, sentence string) []visaFinding {
+	tokens := words(clause)
+	taken := make([]bool, len(tokens))
+	var findings []visaFinding
+
+	for _, topic := range []VisaTopic{TopicSponsorship, TopicRelocation, TopicWorkPermit} {
+		for _, phrase := range visaPhrases[topic] {
+			phraseTokens := words(phrase)
+			at := findFreePhrase(tokens, phraseTokens, taken)
+			if at < 0 {
+				continue
+			}
+			if phrase == "sponsorship" && !containsAny(words(sentence), sponsorshipContext) {
+				continue // "sponsorship for the annual hackathon"
+			}
+			for i := at; i < at+len(phraseTokens); i++ {
+				taken[i] = true
+			}
+
+			negated := negatedAt(tokens, at, at+len(phraseTokens))
+			status := VisaSupported
+			switch {
+			case topic != TopicWorkPermit && negated:
+				status = VisaNotSupported
+			case topic != TopicWorkPermit:
+				status = VisaSupported
+			case containsAny(tokens, helpWords) && negated:
+				status = VisaNotSupported // "we cannot help with work permits"
+			case containsAny(tokens, helpWords):
+				status = VisaSupported // "we help you get your work permit"
+			case negated:
+				// "no work permit required", "non-EU citizens welcome": says nothing either way
+				status = ""
+			default:
+				status = VisaNotSupported // "you have a valid work permit for the EU"
+			}
+
+			if status != "" {
+				findings = append(findings, visaFinding{topic: topic, status: status, evidence: sentence})
+			}
+			break // one finding per topic and clause
+		}
+	}
+
+	return findings
+}
+
+// findFreePhrase returns the index of the first occurrence of phrase in tokens that uses
+// no taken word, or -1
+func findFreePhrase(tokens, phrase []string, taken []bool) int {
+	for offset := 0; offset < len(tokens); {
+		at := findPhrase(tokens[offset:], phrase)
+		if at < 0 {
+			return -1
+		}
+		at += offset
+		free := true
+		for i := at; i < at+len(phrase); i++ {
+			free = free && !taken[i]
+		}
+		if free {
+			return at
+		}
+		offset = at + 1
+	}
+	return -1
+}
+
+// negatedAt reports whether the phrase at tokens[start:end] is negated by a word at most
+// negationWindow words before it, with no contrast or conjunction in between, or right after it
+func negatedAt(tokens []string, start, end int) bool {
+	for i := start - 1; i >= 0 && i >= start-negationWindow; i-- {
+		if negations[tokens[i]] {
+			return true
+		}
+		if contrastWords[tokens[i]] || (conjunctions[tokens[i]] && (i == 0 || !phraseEnds[tokens[i-1]])) {
+			break // the negation belongs to an earlier part of the clause
+		}
+	}
+	for i := end; i < len(tokens) && i < end+2; i++ {
+		if trailingNegations[tokens[i]] {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny reports whether any of the words occurs in tokens
+func containsAny(tokens []string, list []string) bool {
+	for _, token := range tokens {
+		for _, word := range list {
+			if token == word || strings.HasPrefix(token, word+"-") {
+				return true
+			}
+		}
+	}
+	return false
+}
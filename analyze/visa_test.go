@@ -0,0 +1,41 @@
+package analyze
+
+import "testing"
+
+func TestVisa(t *testing.T) {
+	tests := []struct {
+		description string
+		status      VisaStatus
+		topic       VisaTopic
+	}{
+		{"We offer visa sponsorship.", VisaSupported, TopicSponsorship},
+		{"Visa sponsorship is available for this role.", VisaSupported, TopicSponsorship},
+		{"We don't require a degree and we offer visa sponsorship.", VisaSupported, TopicSponsorship},
+		{"No prior experience needed and visa sponsorship available.", VisaSupported, TopicSponsorship},
+		{"Keine Vorkenntnisse nötig und Visa-Sponsoring möglich.", VisaSupported, TopicSponsorship},
+		{"We offer help with your work permit.", VisaSupported, TopicWorkPermit},
+		{"Wir bieten Visa-Sponsoring und Umzugsunterstützung.", VisaSupported, TopicSponsorship},
+		{"Relocation package included.", VisaSupported, TopicRelocation},
+		{"We are unable to offer visa sponsorship.", VisaNotSupported, TopicSponsorship},
+		{"Visa sponsorship is not available.", VisaNotSupported, TopicSponsorship},
+		{"We are not in a position to offer visa sponsorship.", VisaNotSupported, TopicSponsorship},
+		{"We are unable to offer relocation and visa sponsorship.", VisaNotSupported, TopicSponsorship},
+		{"We can't sponsor visas but offer relocation support.", VisaNotSupported, TopicSponsorship},
+		{"Sponsorship is not available for visas.", VisaNotSupported, TopicSponsorship},
+		{"We offer sponsorship for your work visa.", VisaSupported, TopicSponsorship},
+		{"You must hold a valid visa for Germany.", VisaNotSupported, TopicWorkPermit},
+		{"You have the right to work in the EU. Relocation support is available.", VisaNotSupported, TopicWorkPermit},
+		{"Keine Visa-Unterstützung möglich.", VisaNotSupported, TopicSponsorship},
+		{"No work permit required for EU citizens", VisaUnknown, ""},
+		{"We build payment APIs in Go.", VisaUnknown, ""},
+		{"Sponsorship for the annual hackathon.", VisaUnknown, ""},
+		{"We offer conference sponsorship and a learning budget.", VisaUnknown, ""},
+	}
+
+	for _, tt := range tests {
+		got := Visa(tt.description)
+		if got.Status != tt.status || got.Topic != tt.topic {
+			t.Errorf("Visa(%q) = {%s, %s}, want {%s, %s}", tt.description, got.Status, got.Topic, tt.status, tt.topic)
+		}
+	}
+}
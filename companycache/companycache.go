@@ -0,0 +1,180 @@
+package companycache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/goesbams/linkedin-job-scraper/linkedinurl"
+)
+
+// Status tells where a company result came from
+type Status string
+
+const (
+	// Fresh means the company was checked against the site during this lookup
+	Fresh Status = "fresh"
+	// Cached means the result was reused, either from earlier in this run or from the on-disk store
+	Cached Status = "cached"
+)
+
+// Entry is a stored company check result
+type Entry struct {
+	CompanyURL    string          `json:"company_url"`
+	HasIndonesian bool            `json:"has_indonesian"`
+	Employees     json.RawMessage `json:"employees"`
+	CheckedAt     time.Time       `json:"checked_at"`
+}
+
+// Age returns how long ago the entry was checked
+func (e Entry) Age() time.Duration {
+	return time.Since(e.CheckedAt)
+}
+
+// call tracks a check that is in progress so concurrent lookups wait for it
+type call struct {
+	done  chan struct{}
+	entry Entry
+	err   error
+}
+
+// Cache memoizes company checks by canonical company URL.
+// Within a run every company is checked at most once; across runs results are
+// kept in a JSON file and reused until they are older than the TTL.
+type Cache struct {
+	path    string
+	ttl     time.Duration
+	refresh bool
+	saveMu  sync.Mutex // serializes writes to path
+
+	mu       sync.Mutex
+	stored   map[string]Entry // loaded from and written back to path
+	run      map[string]Entry // checked during this run
+	inflight map[string]*call
+}
+
+// Open loads the store at path. An empty path keeps the cache in memory for this
+// run only. With refresh set, stored entries are ignored (but still overwritten).
+func Open(path string, ttl time.Duration, refresh bool) (*Cache, error) {
+	c := &Cache{
+		path:     path,
+		ttl:      ttl,
+		refresh:  refresh,
+		stored:   make(map[string]Entry),
+		run:      make(map[string]Entry),
+		inflight: make(map[string]*call),
+	}
+
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read company cache: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &c.stored); err != nil {
+		return nil, fmt.Errorf("invalid company cache %s: %v", path, err)
+	}
+
+	return c, nil
+}
+
+// Key canonicalizes a company URL so that tracking parameters, sub-pages and
+// case differences all map to the same entry
+func Key(companyURL string) string {
+	return linkedinurl.CanonicalCompanyURL(companyURL)
+}
+
+// Lookup returns the result for companyURL, running check only when no usable
+// result exists. Failed checks are not cached.
+func (c *Cache) Lookup(companyURL string, check func() (Entry, error)) (Entry, Status, error) {
+	key := Key(companyURL)
+
+	c.mu.Lock()
+	if entry, ok := c.run[key]; ok {
+		c.mu.Unlock()
+		return entry, Cached, nil
+	}
+	if entry, ok := c.stored[key]; ok && !c.refresh && (c.ttl <= 0 || entry.Age() < c.ttl) {
+		c.run[key] = entry
+		c.mu.Unlock()
+		return entry, Cached, nil
+	}
+	if pending, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-pending.done
+		return pending.entry, Cached, pending.err
+	}
+
+	pending := &call{done: make(chan struct{})}
+	c.inflight[key] = pending
+	c.mu.Unlock()
+
+	entry, err := check()
+	if err == nil {
+		entry.CompanyURL = key
+		entry.CheckedAt = time.Now()
+	}
+	pending.entry, pending.err = entry, err
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if err == nil {
+		c.run[key] = entry
+		c.stored[key] = entry
+	}
+	c.mu.Unlock()
+	close(pending.done)
+
+	if err != nil {
+		return entry, Fresh, err
+	}
+
+	// Persist after every new result so an interrupted run keeps what it learned;
+	// a failed write is retried by the next save and reported by Close
+	c.save()
+
+	return entry, Fresh, nil
+}
+
+// Close writes the store one last time and reports any error
+func (c *Cache) Close() error {
+	return c.save()
+}
+
+// save writes the store to disk atomically
+func (c *Cache) save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.stored, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(c.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
@@ -0,0 +1,144 @@
+package companycache
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.linkedin.com/company/nusantara-tech", "https://www.linkedin.com/company/nusantara-tech"},
+		{"https://www.linkedin.com/company/Nusantara-Tech/", "https://www.linkedin.com/company/nusantara-tech"},
+		{"https://de.linkedin.com/company/nusantara-tech/people/?trk=public_jobs", "https://www.linkedin.com/company/nusantara-tech"},
+		{" https://www.linkedin.com/company/nusantara-tech/about/ ", "https://www.linkedin.com/company/nusantara-tech"},
+	}
+
+	for _, tt := range tests {
+		if got := Key(tt.url); got != tt.want {
+			t.Errorf("Key(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestLookupChecksEachCompanyOnce(t *testing.T) {
+	cache, err := Open("", 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	checks := 0
+	check := func() (Entry, error) {
+		mu.Lock()
+		checks++
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond) // keep the check in flight while the others arrive
+		return Entry{HasIndonesian: true}, nil
+	}
+
+	urls := []string{
+		"https://www.linkedin.com/company/nusantara-tech",
+		"https://www.linkedin.com/company/Nusantara-Tech/people/",
+		"https://de.linkedin.com/company/nusantara-tech?trk=public_jobs",
+		"https://www.linkedin.com/company/nusantara-tech/",
+	}
+	statuses := make([]Status, len(urls))
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			entry, status, err := cache.Lookup(url, check)
+			if err != nil || !entry.HasIndonesian {
+				t.Errorf("Lookup(%q) = %+v, %v", url, entry, err)
+			}
+			statuses[i] = status
+		}(i, url)
+	}
+	wg.Wait()
+
+	if checks != 1 {
+		t.Errorf("checked the company %d times, want once", checks)
+	}
+	fresh := 0
+	for _, status := range statuses {
+		if status == Fresh {
+			fresh++
+		}
+	}
+	if fresh != 1 {
+		t.Errorf("statuses = %v, want one fresh lookup and the rest cached", statuses)
+	}
+}
+
+func TestLookupDoesNotCacheFailures(t *testing.T) {
+	cache, err := Open("", 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failure := errors.New("people page timed out")
+	if _, _, err := cache.Lookup("https://www.linkedin.com/company/berlin-cloud", func() (Entry, error) {
+		return Entry{}, failure
+	}); err != failure {
+		t.Fatalf("err = %v, want the check's error", err)
+	}
+
+	checked := false
+	_, status, err := cache.Lookup("https://www.linkedin.com/company/berlin-cloud", func() (Entry, error) {
+		checked = true
+		return Entry{}, nil
+	})
+	if err != nil || !checked || status != Fresh {
+		t.Errorf("lookup after a failure: checked %v, status %s, err %v; want a fresh check", checked, status, err)
+	}
+}
+
+func TestStoreAcrossRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "companies.json")
+	const company = "https://www.linkedin.com/company/nusantara-tech"
+
+	first, err := Open(path, time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := first.Lookup(company, func() (Entry, error) { return Entry{HasIndonesian: true}, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		refresh bool
+		status  Status
+	}{
+		{"within the TTL", time.Hour, false, Cached},
+		{"no TTL", 0, false, Cached},
+		{"expired", time.Nanosecond, false, Fresh},
+		{"refresh", time.Hour, true, Fresh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, err := Open(path, tt.ttl, tt.refresh)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry, status, err := cache.Lookup(company, func() (Entry, error) { return Entry{HasIndonesian: true}, nil })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != tt.status || !entry.HasIndonesian {
+				t.Errorf("Lookup = %s %+v, want %s with the stored result", status, entry, tt.status)
+			}
+		})
+	}
+}
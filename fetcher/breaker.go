@@ -0,0 +1,78 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrCircuitOpen is returned for every request once the breaker has tripped
+var ErrCircuitOpen = errors.New("circuit breaker open: the site is blocking or throttling us")
+
+// CircuitBreaker stops all fetching after a run of consecutive block or throttle
+// signals (status 999 or 429). It does not try to work around the block: once
+// open it stays open for the rest of the run.
+type CircuitBreaker struct {
+	next      Fetcher
+	threshold int
+
+	mu          sync.Mutex
+	consecutive int
+	tripped     error
+}
+
+// NewCircuitBreaker opens after threshold consecutive block/throttle responses
+func NewCircuitBreaker(next Fetcher, threshold int) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{next: next, threshold: threshold}
+}
+
+// Fetch forwards the request unless the breaker is open
+func (b *CircuitBreaker) Fetch(ctx context.Context, url string) (*Response, error) {
+	b.mu.Lock()
+	tripped := b.tripped
+	b.mu.Unlock()
+	if tripped != nil {
+		return nil, tripped
+	}
+
+	resp, err := b.next.Fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !BlockSignal(resp.StatusCode) {
+		b.consecutive = 0
+		return resp, nil
+	}
+
+	b.consecutive++
+	if b.consecutive >= b.threshold && b.tripped == nil {
+		b.tripped = fmt.Errorf("%w (%d consecutive block/throttle responses, last: status %d from %s)",
+			ErrCircuitOpen, b.consecutive, resp.StatusCode, url)
+	}
+	if b.tripped != nil {
+		return nil, b.tripped
+	}
+
+	return resp, nil
+}
+
+// Tripped returns the reason the breaker opened, or nil if it is still closed
+func (b *CircuitBreaker) Tripped() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tripped
+}
+
+// BlockSignal reports whether a status code means the site is refusing or throttling us
+func BlockSignal(statusCode int) bool {
+	return statusCode == 999 || statusCode == http.StatusTooManyRequests
+}
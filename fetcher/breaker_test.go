@@ -0,0 +1,68 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// statusFetcher answers the n-th request with the n-th status code
+type statusFetcher struct {
+	codes []int
+	calls int
+}
+
+func (f *statusFetcher) Fetch(ctx context.Context, url string) (*Response, error) {
+	code := f.codes[f.calls]
+	f.calls++
+	return &Response{URL: url, StatusCode: code, Status: http.StatusText(code), Header: http.Header{}}, nil
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		codes     []int
+		tripAt    int // the request that opens the breaker, 0 if it stays closed
+	}{
+		{"never blocked", 3, []int{200, 200, 200, 200}, 0},
+		{"blocks interrupted by a page", 3, []int{999, 999, 200, 999, 999, 200}, 0},
+		{"sustained 999", 3, []int{200, 999, 999, 999}, 4},
+		{"throttled", 2, []int{429, 429}, 2},
+		{"429 and 999 both count", 3, []int{429, 999, 429}, 3},
+		{"server errors are not blocks", 2, []int{503, 503, 503}, 0},
+		{"threshold below one", 0, []int{999}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &statusFetcher{codes: tt.codes}
+			breaker := NewCircuitBreaker(next, tt.threshold)
+
+			for i := range tt.codes {
+				_, err := breaker.Fetch(context.Background(), "https://www.linkedin.com/jobs/search")
+				tripped := tt.tripAt > 0 && i+1 >= tt.tripAt
+				if tripped != errors.Is(err, ErrCircuitOpen) {
+					t.Fatalf("request %d: err = %v, want open %v", i+1, err, tripped)
+				}
+			}
+			if open := breaker.Tripped() != nil; open != (tt.tripAt > 0) {
+				t.Errorf("Tripped() = %v, want open %v", breaker.Tripped(), tt.tripAt > 0)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerStaysOpen(t *testing.T) {
+	next := &statusFetcher{codes: []int{999, 200}}
+	breaker := NewCircuitBreaker(next, 1)
+
+	breaker.Fetch(context.Background(), "https://www.linkedin.com/jobs/search")
+	if _, err := breaker.Fetch(context.Background(), "https://www.linkedin.com/jobs/search"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("err = %v after tripping, want ErrCircuitOpen", err)
+	}
+	if next.calls != 1 {
+		t.Errorf("open breaker forwarded %d requests, want none after the trip", next.calls-1)
+	}
+}
@@ -0,0 +1,75 @@
+package fetcher
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AcceptEncoding lists only the content codings DecodeBody can undo
+const AcceptEncoding = "gzip, deflate"
+
+// UnsupportedEncodingError is returned for a Content-Encoding DecodeBody cannot decode
+type UnsupportedEncodingError struct {
+	Encoding string
+}
+
+func (e *UnsupportedEncodingError) Error() string {
+	return fmt.Sprintf("unsupported content encoding %q", e.Encoding)
+}
+
+// DecodeBody undoes the codings listed in a Content-Encoding header.
+// Codings are applied in the order listed, so they are removed in reverse.
+func DecodeBody(contentEncoding string, body []byte) ([]byte, error) {
+	var codings []string
+	for _, coding := range strings.Split(contentEncoding, ",") {
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "" && coding != "identity" {
+			codings = append(codings, coding)
+		}
+	}
+
+	for i := len(codings) - 1; i >= 0; i-- {
+		var err error
+		switch codings[i] {
+		case "gzip", "x-gzip":
+			body, err = decodeGzip(body)
+		case "deflate":
+			body, err = decodeDeflate(body)
+		default:
+			return nil, &UnsupportedEncodingError{Encoding: codings[i]}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s body: %v", codings[i], err)
+		}
+	}
+
+	return body, nil
+}
+
+// decodeGzip decompresses a gzip stream
+func decodeGzip(body []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// decodeDeflate decompresses "deflate" bodies. The spec says zlib-wrapped, but
+// some servers send raw DEFLATE, so that is tried when the zlib header is missing.
+func decodeDeflate(body []byte) ([]byte, error) {
+	if reader, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+		defer reader.Close()
+		return io.ReadAll(reader)
+	}
+
+	reader := flate.NewReader(bytes.NewReader(body))
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
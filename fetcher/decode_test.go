@@ -0,0 +1,153 @@
+package fetcher
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const decodedPage = "<html><body><h1>Senior Go Developer</h1></body></html>"
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zlibbed(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func rawDeflated(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeBody(t *testing.T) {
+	body := []byte(decodedPage)
+	tests := []struct {
+		name        string
+		encoding    string
+		body        []byte
+		unsupported string // the coding reported by *UnsupportedEncodingError
+	}{
+		{name: "no header", encoding: "", body: body},
+		{name: "identity", encoding: "identity", body: body},
+		{name: "gzip", encoding: "gzip", body: gzipped(t, body)},
+		{name: "x-gzip", encoding: "x-gzip", body: gzipped(t, body)},
+		{name: "zlib deflate", encoding: "deflate", body: zlibbed(t, body)},
+		{name: "raw deflate", encoding: "deflate", body: rawDeflated(t, body)},
+		{name: "stacked", encoding: "gzip, deflate", body: zlibbed(t, gzipped(t, body))},
+		{name: "upper case", encoding: "GZIP", body: gzipped(t, body)},
+		{name: "brotli", encoding: "br", body: body, unsupported: "br"},
+		{name: "unsupported after gzip", encoding: "gzip, br", body: body, unsupported: "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeBody(tt.encoding, tt.body)
+			if tt.unsupported != "" {
+				var unsupported *UnsupportedEncodingError
+				if !errors.As(err, &unsupported) || unsupported.Encoding != tt.unsupported {
+					t.Fatalf("err = %v, want *UnsupportedEncodingError for %q", err, tt.unsupported)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeBody(%q): %v", tt.encoding, err)
+			}
+			if string(got) != decodedPage {
+				t.Errorf("DecodeBody(%q) = %q, want %q", tt.encoding, got, decodedPage)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyCorrupt(t *testing.T) {
+	if _, err := DecodeBody("gzip", []byte(decodedPage)); err == nil {
+		t.Error("a plain body labelled gzip decoded without error")
+	}
+}
+
+func TestHTTPFetcherDecodes(t *testing.T) {
+	body := []byte(decodedPage)
+	tests := []struct {
+		encoding string
+		body     []byte
+		wantErr  bool
+	}{
+		{"", body, false},
+		{"gzip", gzipped(t, body), false},
+		{"deflate", zlibbed(t, body), false},
+		{"deflate", rawDeflated(t, body), false},
+		{"gzip, deflate", zlibbed(t, gzipped(t, body)), false},
+		{"br", body, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept-Encoding"); got != AcceptEncoding {
+					t.Errorf("Accept-Encoding = %q, want %q", got, AcceptEncoding)
+				}
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			resp, err := NewHTTPFetcher(5*time.Second).Fetch(context.Background(), server.URL)
+			if tt.wantErr {
+				var unsupported *UnsupportedEncodingError
+				if !errors.As(err, &unsupported) {
+					t.Fatalf("err = %v, want *UnsupportedEncodingError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(resp.Body) != decodedPage {
+				t.Errorf("body = %q, want %q", resp.Body, decodedPage)
+			}
+			if got := resp.Header.Get("Content-Encoding"); got != "" {
+				t.Errorf("Content-Encoding %q left on a decoded response", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+)
+
+// Response holds everything the scraper needs from a fetched page
+type Response struct {
+	URL        string      `json:"url"`
+	FinalURL   string      `json:"final_url,omitempty"` // where redirects ended, when the request was redirected
+	StatusCode int         `json:"status_code"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"-"`
+}
+
+// Fetcher retrieves the page behind a URL, giving up when ctx is cancelled
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (*Response, error)
+}
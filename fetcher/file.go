@@ -0,0 +1,96 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// unsafeChars matches characters that should not appear in fixture file names
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileFetcher serves fixture pages from a directory instead of the network.
+//
+// A URL such as https://www.linkedin.com/company/acme/people/ maps to
+// <dir>/www.linkedin.com/company/acme/people/index.html. When the URL has a
+// query string the fetcher first looks for a file with the sanitized query
+// appended (search__keywords_go_location_Germany.html) and then falls back to
+// the query-less page, so one fixture can answer every page of a search.
+type FileFetcher struct {
+	dir string
+}
+
+// NewFileFetcher creates a fetcher that reads pages from dir
+func NewFileFetcher(dir string) (*FileFetcher, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("fixture directory not found: %v", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("fixture path %s is not a directory", dir)
+	}
+	return &FileFetcher{dir: dir}, nil
+}
+
+// Fetch returns the fixture for url, or a 404 response if none exists
+func (f *FileFetcher) Fetch(ctx context.Context, rawURL string) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	candidates, err := FixturePaths(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		body, err := os.ReadFile(filepath.Join(f.dir, candidate))
+		if err != nil {
+			continue
+		}
+		return &Response{
+			URL:        rawURL,
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+			Body:       body,
+		}, nil
+	}
+
+	return &Response{
+		URL:        rawURL,
+		StatusCode: http.StatusNotFound,
+		Status:     "404 Not Found",
+		Header:     http.Header{},
+	}, nil
+}
+
+// FixturePaths returns the relative fixture paths tried for a URL, most specific first
+func FixturePaths(rawURL string) ([]string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", rawURL, err)
+	}
+
+	base := filepath.Join(u.Host, filepath.FromSlash(u.Path))
+	if u.Path == "" || strings.HasSuffix(u.Path, "/") {
+		base = filepath.Join(base, "index")
+	}
+
+	var paths []string
+	if u.RawQuery != "" {
+		query := unsafeChars.ReplaceAllString(u.Query().Encode(), "_")
+		paths = append(paths, base+"__"+query+".html")
+	}
+	if filepath.Ext(u.Path) != "" {
+		paths = append(paths, base) // e.g. robots.txt is served as-is
+	}
+	paths = append(paths, base+".html")
+
+	return paths, nil
+}
@@ -0,0 +1,92 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultUserAgent is the browser User-Agent sent unless SetUserAgent overrides it
+const DefaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+// HTTPFetcher fetches pages from the live site using browser-like headers
+type HTTPFetcher struct {
+	client  *http.Client
+	headers map[string]string
+}
+
+// NewHTTPFetcher creates a fetcher backed by a real HTTP client
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	return &HTTPFetcher{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		// Enhanced headers to appear more like a real browser
+		headers: map[string]string{
+			"User-Agent":                DefaultUserAgent,
+			"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8",
+			"Accept-Language":           "en-US,en;q=0.9",
+			"Accept-Encoding":           AcceptEncoding,
+			"Connection":                "keep-alive",
+			"Upgrade-Insecure-Requests": "1",
+			"Sec-Fetch-Dest":            "document",
+			"Sec-Fetch-Mode":            "navigate",
+			"Sec-Fetch-Site":            "none",
+			"Cache-Control":             "max-age=0",
+			"DNT":                       "1",
+		},
+	}
+}
+
+// SetUserAgent replaces the User-Agent header sent with every request
+func (f *HTTPFetcher) SetUserAgent(userAgent string) {
+	f.headers["User-Agent"] = userAgent
+}
+
+// Fetch performs a GET request and reads the whole body, decoding any compression.
+// Setting Accept-Encoding ourselves turns off Go's transparent gzip support, so the
+// body is decoded here and Content-Encoding is removed from the returned headers.
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range f.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if encoding := resp.Header.Get("Content-Encoding"); encoding != "" {
+		body, err = DecodeBody(encoding, body)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", url, err)
+		}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+	}
+
+	response := &Response{
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Body:       body,
+	}
+	if resp.Request.Response != nil { // set on requests made to follow a redirect
+		response.FinalURL = resp.Request.URL.String()
+	}
+	return response, nil
+}
@@ -0,0 +1,168 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExhausted is returned once the per-run request cap has been spent
+var ErrBudgetExhausted = errors.New("request budget exhausted")
+
+// LimiterStats summarizes how the limiter was used during a run
+type LimiterStats struct {
+	Requests int           // requests that were let through
+	Waited   time.Duration // total time callers spent waiting for a token
+}
+
+// bucket is a token bucket for a single host
+type bucket struct {
+	tokens    float64
+	last      time.Time
+	perSecond float64 // host-specific rate, e.g. from robots.txt Crawl-delay; 0 uses the global rate
+}
+
+// RateLimiter is the single request scheduler every fetch goes through.
+// It keeps one token bucket per host and an optional hard cap on the total
+// number of requests in a run.
+type RateLimiter struct {
+	next        Fetcher
+	perSecond   float64 // refill rate, 0 disables pacing
+	burst       float64
+	maxRequests int // 0 means unlimited
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	stats   LimiterStats
+}
+
+// NewRateLimiter paces requests through next to requestsPerMinute per host.
+// A requestsPerMinute of 0 disables pacing; maxRequests of 0 disables the cap.
+func NewRateLimiter(next Fetcher, requestsPerMinute float64, burst int, maxRequests int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		next:        next,
+		perSecond:   requestsPerMinute / 60,
+		burst:       float64(burst),
+		maxRequests: maxRequests,
+		buckets:     make(map[string]*bucket),
+	}
+}
+
+// Fetch waits for a token for the URL's host and then performs the request
+func (l *RateLimiter) Fetch(ctx context.Context, rawURL string) (*Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", rawURL, err)
+	}
+
+	wait, err := l.reserve(u.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.cancel(u.Host)
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		l.mu.Lock()
+		l.stats.Waited += wait
+		l.mu.Unlock()
+	}
+
+	return l.next.Fetch(ctx, rawURL)
+}
+
+// reserve takes a token for host and returns how long the caller must wait before using it
+func (l *RateLimiter) reserve(host string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxRequests > 0 && l.stats.Requests >= l.maxRequests {
+		return 0, fmt.Errorf("%w: %d requests already made", ErrBudgetExhausted, l.stats.Requests)
+	}
+	l.stats.Requests++
+
+	now := time.Now()
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[host] = b
+	}
+
+	rate := l.perSecond
+	if b.perSecond > 0 {
+		rate = b.perSecond
+	}
+	if rate <= 0 {
+		return 0, nil
+	}
+
+	// Refill, then take one token; a negative balance queues the caller behind earlier reservations
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0, nil
+	}
+	return time.Duration(-b.tokens / rate * float64(time.Second)), nil
+}
+
+// SetCrawlDelay slows host down to at most one request per delay.
+// It never speeds a host up beyond the configured requests per minute.
+func (l *RateLimiter) SetCrawlDelay(host string, delay time.Duration) {
+	if delay <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rate := 1 / delay.Seconds()
+	if l.perSecond > 0 && l.perSecond < rate {
+		rate = l.perSecond
+	}
+
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: 1, last: time.Now()}
+		l.buckets[host] = b
+	}
+	b.perSecond = rate
+	if b.tokens > 1 {
+		b.tokens = 1
+	}
+}
+
+// cancel returns a reserved token that was never used
+func (l *RateLimiter) cancel(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests--
+	if b, ok := l.buckets[host]; ok {
+		b.tokens++
+	}
+}
+
+// Stats returns the requests spent and time waited so far
+func (l *RateLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
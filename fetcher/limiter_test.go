@@ -0,0 +1,39 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// okFetcher answers every request with an empty 200 response
+type okFetcher struct{}
+
+func (okFetcher) Fetch(ctx context.Context, url string) (*Response, error) {
+	return &Response{URL: url, StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}}, nil
+}
+
+func TestCrawlDelayWithoutPacing(t *testing.T) {
+	limiter := NewRateLimiter(okFetcher{}, 0, 1, 0)
+	limiter.SetCrawlDelay("www.linkedin.com", 50*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if _, err := limiter.Fetch(context.Background(), "https://www.linkedin.com/jobs/search"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if waited := limiter.Stats().Waited; waited < 40*time.Millisecond {
+		t.Errorf("waited %v between requests, want about the 50ms Crawl-delay", waited)
+	}
+
+	if _, err := limiter.Fetch(context.Background(), "https://example.com/"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := limiter.Fetch(context.Background(), "https://example.com/"); err != nil {
+		t.Fatal(err)
+	}
+	if waited := limiter.Stats().Waited; waited > 100*time.Millisecond {
+		t.Errorf("waited %v in total, want no pacing for hosts without a Crawl-delay", waited)
+	}
+}
@@ -0,0 +1,113 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// indexFile is the name of the session index written by Recorder
+const indexFile = "index.json"
+
+// Exchange describes one recorded request in the session index
+type Exchange struct {
+	Seq        int    `json:"seq"`
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+	MetaFile   string `json:"meta_file"`
+	BodyFile   string `json:"body_file"`
+}
+
+// SessionIndex lists every exchange of a recorded session in request order
+type SessionIndex struct {
+	RecordedAt string     `json:"recorded_at"`
+	Exchanges  []Exchange `json:"exchanges"`
+}
+
+// Recorder wraps another fetcher and writes every exchange to disk
+type Recorder struct {
+	next  Fetcher
+	dir   string
+	mu    sync.Mutex
+	index SessionIndex
+}
+
+// NewRecorder creates a recorder that saves exchanges made through next into dir
+func NewRecorder(next Fetcher, dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create record directory: %v", err)
+	}
+
+	r := &Recorder{
+		next:  next,
+		dir:   dir,
+		index: SessionIndex{RecordedAt: time.Now().Format(time.RFC3339)},
+	}
+
+	// Write an empty index up front so an interrupted session is still replayable
+	if err := r.writeIndex(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Fetch forwards the request and records the response before returning it
+func (r *Recorder) Fetch(ctx context.Context, url string) (*Response, error) {
+	resp, err := r.next.Fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.record(resp); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %v", url, err)
+	}
+
+	return resp, nil
+}
+
+// record writes the response metadata and body and updates the index
+func (r *Recorder) record(resp *Response) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seq := len(r.index.Exchanges) + 1
+	exchange := Exchange{
+		Seq:        seq,
+		URL:        resp.URL,
+		StatusCode: resp.StatusCode,
+		MetaFile:   fmt.Sprintf("%04d.json", seq),
+		BodyFile:   fmt.Sprintf("%04d.html", seq),
+	}
+
+	meta, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, exchange.MetaFile), meta, 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, exchange.BodyFile), resp.Body, 0644); err != nil {
+		return err
+	}
+
+	r.index.Exchanges = append(r.index.Exchanges, exchange)
+	return r.writeIndex()
+}
+
+// writeIndex rewrites the session index file
+func (r *Recorder) writeIndex() error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false) // keep '&' in URLs readable
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.index); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, indexFile), buf.Bytes(), 0644)
+}
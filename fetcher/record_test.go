@@ -0,0 +1,106 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// pageFetcher serves each URL the next of its pages, repeating the last one
+type pageFetcher struct {
+	pages  map[string][]string
+	served map[string]int
+}
+
+func (f *pageFetcher) Fetch(ctx context.Context, url string) (*Response, error) {
+	pages := f.pages[url]
+	if len(pages) == 0 {
+		return &Response{URL: url, StatusCode: http.StatusNotFound, Status: "404 Not Found", Header: http.Header{}}, nil
+	}
+	n := f.served[url]
+	if n >= len(pages) {
+		n = len(pages) - 1
+	}
+	f.served[url]++
+	header := http.Header{}
+	header.Set("Content-Type", "text/html")
+	return &Response{URL: url, StatusCode: http.StatusOK, Status: "200 OK", Header: header, Body: []byte(pages[n])}, nil
+}
+
+func TestRecordReplay(t *testing.T) {
+	const (
+		search  = "https://www.linkedin.com/jobs/search?keywords=golang&location=Germany&start=0"
+		company = "https://www.linkedin.com/company/nusantara-tech"
+		missing = "https://www.linkedin.com/company/berlin-cloud"
+	)
+	live := &pageFetcher{
+		pages: map[string][]string{
+			search:  {"<html>page 1</html>", "<html>page 1 again</html>"},
+			company: {"<html>company</html>"},
+		},
+		served: make(map[string]int),
+	}
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(live, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, url := range []string{search, company, search} {
+		if _, err := recorder.Fetch(context.Background(), url); err != nil {
+			t.Fatalf("record %s: %v", url, err)
+		}
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		url  string
+		body string
+	}{
+		{search, "<html>page 1</html>"},
+		{search, "<html>page 1 again</html>"},
+		{search, "<html>page 1 again</html>"}, // the last recording repeats
+		{company, "<html>company</html>"},
+	}
+	for _, tt := range tests {
+		resp, err := replayer.Fetch(context.Background(), tt.url)
+		if err != nil {
+			t.Fatalf("replay %s: %v", tt.url, err)
+		}
+		if string(resp.Body) != tt.body {
+			t.Errorf("replay %s body = %q, want %q", tt.url, resp.Body, tt.body)
+		}
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/html" {
+			t.Errorf("replay %s = %d %v, want the recorded status and header", tt.url, resp.StatusCode, resp.Header)
+		}
+	}
+
+	var notRecorded *NotRecordedError
+	if _, err := replayer.Fetch(context.Background(), missing); !errors.As(err, &notRecorded) || notRecorded.URL != missing {
+		t.Errorf("replay of an unrecorded URL: err = %v, want *NotRecordedError", err)
+	}
+	if misses := replayer.Misses(); len(misses) != 1 || misses[0] != missing {
+		t.Errorf("Misses() = %v, want [%s]", misses, missing)
+	}
+}
+
+func TestReplayEmptySession(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewRecorder(&pageFetcher{}, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	// An interrupted session still has an index, it just answers nothing
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer on an empty session: %v", err)
+	}
+	var notRecorded *NotRecordedError
+	if _, err := replayer.Fetch(context.Background(), "https://www.linkedin.com/"); !errors.As(err, &notRecorded) {
+		t.Errorf("err = %v, want *NotRecordedError", err)
+	}
+}
@@ -0,0 +1,101 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// NotRecordedError is returned when a replayed session has no entry for a URL
+type NotRecordedError struct {
+	URL string
+}
+
+func (e *NotRecordedError) Error() string {
+	return fmt.Sprintf("replay: no recorded response for %s", e.URL)
+}
+
+// Replayer answers requests from a session written by Recorder, without any network access.
+//
+// When the same URL was recorded several times the responses are served in
+// recording order, and the last one is repeated once they run out.
+type Replayer struct {
+	dir       string
+	mu        sync.Mutex
+	exchanges map[string][]Exchange
+	served    map[string]int
+	misses    []string
+}
+
+// NewReplayer loads the session index from dir
+func NewReplayer(dir string) (*Replayer, error) {
+	data, err := os.ReadFile(filepath.Join(dir, indexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay index: %v", err)
+	}
+
+	var index SessionIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid replay index %s: %v", filepath.Join(dir, indexFile), err)
+	}
+
+	r := &Replayer{
+		dir:       dir,
+		exchanges: make(map[string][]Exchange),
+		served:    make(map[string]int),
+	}
+	for _, exchange := range index.Exchanges {
+		r.exchanges[exchange.URL] = append(r.exchanges[exchange.URL], exchange)
+	}
+
+	return r, nil
+}
+
+// Fetch returns the recorded response for url or a *NotRecordedError
+func (r *Replayer) Fetch(ctx context.Context, url string) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	candidates := r.exchanges[url]
+	if len(candidates) == 0 {
+		r.misses = append(r.misses, url)
+		r.mu.Unlock()
+		return nil, &NotRecordedError{URL: url}
+	}
+	n := r.served[url]
+	if n >= len(candidates) {
+		n = len(candidates) - 1
+	}
+	r.served[url]++
+	exchange := candidates[n]
+	r.mu.Unlock()
+
+	meta, err := os.ReadFile(filepath.Join(r.dir, exchange.MetaFile))
+	if err != nil {
+		return nil, fmt.Errorf("replay: missing metadata for %s: %v", url, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(meta, &resp); err != nil {
+		return nil, fmt.Errorf("replay: invalid metadata %s: %v", exchange.MetaFile, err)
+	}
+
+	resp.Body, err = os.ReadFile(filepath.Join(r.dir, exchange.BodyFile))
+	if err != nil {
+		return nil, fmt.Errorf("replay: missing body for %s: %v", url, err)
+	}
+
+	return &resp, nil
+}
+
+// Misses returns every URL that was requested but not found in the session
+func (r *Replayer) Misses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.misses...)
+}
@@ -0,0 +1,185 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how transient failures are retried
+type RetryPolicy struct {
+	MaxAttempts   int           // total attempts including the first one
+	BaseDelay     time.Duration // backoff before the second attempt, doubled after each retry
+	MaxDelay      time.Duration // upper bound for the computed backoff
+	MaxRetryAfter time.Duration // give up instead of waiting when Retry-After asks for longer than this
+}
+
+// DefaultRetryPolicy returns a conservative policy suitable for LinkedIn
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:   3,
+		BaseDelay:     5 * time.Second,
+		MaxDelay:      60 * time.Second,
+		MaxRetryAfter: 5 * time.Minute,
+	}
+}
+
+// RetryError reports a request that still failed after the retry policy gave up
+type RetryError struct {
+	URL        string
+	Attempts   int
+	StatusCode int   // last status received, 0 if the last attempt had a transport error
+	Err        error // last transport error, if any
+	Reason     string
+}
+
+func (e *RetryError) Error() string {
+	cause := fmt.Sprintf("status %d", e.StatusCode)
+	if e.Err != nil {
+		cause = e.Err.Error()
+	}
+	if e.Reason != "" {
+		cause += " (" + e.Reason + ")"
+	}
+	return fmt.Sprintf("gave up on %s after %d attempt(s): %s", e.URL, e.Attempts, cause)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// RetryFetcher retries 429 and 5xx responses and transport errors with
+// exponential backoff and jitter. A Retry-After header is always honoured:
+// the fetcher never waits less than the server asked for.
+type RetryFetcher struct {
+	next   Fetcher
+	policy RetryPolicy
+	logf   func(format string, args ...interface{})
+}
+
+// NewRetryFetcher wraps next with policy; logf receives one line per attempt and may be nil
+func NewRetryFetcher(next Fetcher, policy RetryPolicy, logf func(format string, args ...interface{})) *RetryFetcher {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if logf == nil {
+		logf = func(string, ...interface{}) {}
+	}
+	return &RetryFetcher{next: next, policy: policy, logf: logf}
+}
+
+// Fetch performs the request, retrying transient failures according to the policy
+func (f *RetryFetcher) Fetch(ctx context.Context, url string) (*Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := f.next.Fetch(ctx, url)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if errors.Is(err, ErrBudgetExhausted) || errors.Is(err, ErrCircuitOpen) {
+			return nil, err // retrying cannot help once the run has to stop
+		}
+		var unsupported *UnsupportedEncodingError
+		if errors.As(err, &unsupported) {
+			return nil, err // the server will send the same encoding again
+		}
+		var disallowed *DisallowedError
+		if errors.As(err, &disallowed) {
+			return nil, err // never work around robots.txt
+		}
+		var notRecorded *NotRecordedError
+		if errors.As(err, &notRecorded) {
+			return nil, err // a replayed session answers the same way every time
+		}
+
+		if err == nil && !Retryable(resp.StatusCode) {
+			if attempt > 1 {
+				f.logf("Attempt %d/%d for %s succeeded with status %d", attempt, f.policy.MaxAttempts, url, resp.StatusCode)
+			}
+			return resp, nil
+		}
+
+		failure := &RetryError{URL: url, Attempts: attempt, Err: err}
+		if err != nil {
+			f.logf("Attempt %d/%d for %s failed: %v", attempt, f.policy.MaxAttempts, url, err)
+		} else {
+			failure.StatusCode = resp.StatusCode
+			f.logf("Attempt %d/%d for %s returned status %d", attempt, f.policy.MaxAttempts, url, resp.StatusCode)
+		}
+
+		if attempt >= f.policy.MaxAttempts {
+			return nil, failure
+		}
+
+		wait := f.backoff(attempt)
+		if err == nil {
+			if retryAfter, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > f.policy.MaxRetryAfter {
+					failure.Reason = fmt.Sprintf("server asked to retry after %v", retryAfter)
+					return nil, failure
+				}
+				if retryAfter > wait {
+					wait = retryAfter
+				}
+				f.logf("Server sent Retry-After %v for %s", retryAfter, url)
+			}
+		}
+
+		f.logf("Waiting %v before attempt %d/%d", wait, attempt+1, f.policy.MaxAttempts)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the jittered exponential delay after the given attempt
+func (f *RetryFetcher) backoff(attempt int) time.Duration {
+	delay := f.policy.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > f.policy.MaxDelay {
+		delay = f.policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Equal jitter: keep half the delay and randomize the other half
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// Retryable reports whether a status code indicates a transient failure worth retrying
+func Retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || (statusCode >= 500 && statusCode <= 599)
+}
+
+// ParseRetryAfter parses a Retry-After header given either as seconds or as an HTTP date
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		if wait := when.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,29 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryFetcherNotRecorded(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewRecorder(&pageFetcher{}, dir); err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retry := NewRetryFetcher(replayer, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}, nil)
+	const url = "https://www.linkedin.com/company/berlin-cloud"
+	var notRecorded *NotRecordedError
+	if _, err := retry.Fetch(context.Background(), url); !errors.As(err, &notRecorded) {
+		t.Fatalf("err = %v, want *NotRecordedError", err)
+	}
+	if misses := replayer.Misses(); len(misses) != 1 {
+		t.Errorf("Misses() = %v, want the URL once, not once per attempt", misses)
+	}
+}
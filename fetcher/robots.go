@@ -0,0 +1,306 @@
+package fetcher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DisallowedError is returned for URLs that robots.txt does not allow us to fetch
+type DisallowedError struct {
+	URL  string
+	Rule string
+}
+
+func (e *DisallowedError) Error() string {
+	return fmt.Sprintf("robots.txt disallows %s (rule: Disallow: %s)", e.URL, e.Rule)
+}
+
+// robotsRule is a single Allow or Disallow line
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// robotsGroup holds the rules that apply to our user agent on one host
+type robotsGroup struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// RobotsFetcher checks every URL against the host's robots.txt before fetching it.
+// robots.txt is fetched once per host per run through the wrapped fetcher.
+type RobotsFetcher struct {
+	next         Fetcher
+	agent        string
+	onCrawlDelay func(host string, delay time.Duration)
+
+	mu         sync.Mutex
+	hosts      map[string]*robotsGroup
+	unrecorded map[string]error // hosts whose robots.txt a replayed session lacks
+	loading    map[string]chan struct{}
+	disallowed []string
+}
+
+// NewRobotsFetcher wraps next; agent is the product token matched against User-agent lines
+// (e.g. "Mozilla" or "IndoJobScraper"). onCrawlDelay, if set, receives each host's Crawl-delay.
+func NewRobotsFetcher(next Fetcher, agent string, onCrawlDelay func(host string, delay time.Duration)) *RobotsFetcher {
+	return &RobotsFetcher{
+		next:         next,
+		agent:        strings.ToLower(agent),
+		onCrawlDelay: onCrawlDelay,
+		hosts:        make(map[string]*robotsGroup),
+		unrecorded:   make(map[string]error),
+		loading:      make(map[string]chan struct{}),
+	}
+}
+
+// Fetch returns a *DisallowedError without touching the network if robots.txt forbids url
+func (f *RobotsFetcher) Fetch(ctx context.Context, rawURL string) (*Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", rawURL, err)
+	}
+
+	group, err := f.groupFor(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowed, rule := group.allows(u); !allowed {
+		f.mu.Lock()
+		f.disallowed = append(f.disallowed, rawURL)
+		f.mu.Unlock()
+		return nil, &DisallowedError{URL: rawURL, Rule: rule}
+	}
+
+	return f.next.Fetch(ctx, rawURL)
+}
+
+// Disallowed returns every URL that was skipped because of robots.txt
+func (f *RobotsFetcher) Disallowed() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.disallowed...)
+}
+
+// groupFor returns the cached rules for u's host, loading robots.txt on first use
+func (f *RobotsFetcher) groupFor(ctx context.Context, u *url.URL) (*robotsGroup, error) {
+	host := u.Host
+
+	for {
+		f.mu.Lock()
+		if group, ok := f.hosts[host]; ok {
+			f.mu.Unlock()
+			return group, nil
+		}
+		if err, ok := f.unrecorded[host]; ok {
+			f.mu.Unlock()
+			return nil, err
+		}
+		if wait, ok := f.loading[host]; ok {
+			f.mu.Unlock()
+			select {
+			case <-wait:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		done := make(chan struct{})
+		f.loading[host] = done
+		f.mu.Unlock()
+
+		group, err := f.load(ctx, u)
+
+		f.mu.Lock()
+		delete(f.loading, host)
+		var notRecorded *NotRecordedError
+		if err == nil {
+			f.hosts[host] = group
+		} else if errors.As(err, &notRecorded) {
+			f.unrecorded[host] = err // asking the session again would only repeat the miss
+		}
+		f.mu.Unlock()
+		close(done)
+
+		if err != nil {
+			return nil, err
+		}
+		if group.crawlDelay > 0 && f.onCrawlDelay != nil {
+			f.onCrawlDelay(host, group.crawlDelay)
+		}
+		return group, nil
+	}
+}
+
+// load fetches and parses robots.txt for u's host.
+// Following RFC 9309, a 4xx means no restrictions while a 5xx or network
+// failure means the whole host is treated as disallowed. A replayed session
+// without robots.txt is reported as such rather than as a disallowed host.
+func (f *RobotsFetcher) load(ctx context.Context, u *url.URL) (*robotsGroup, error) {
+	robotsURL := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"}).String()
+
+	resp, err := f.next.Fetch(ctx, robotsURL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var notRecorded *NotRecordedError
+		if errors.As(err, &notRecorded) {
+			return nil, err
+		}
+		return disallowAll(), nil
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return parseRobots(resp.Body, f.agent), nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &robotsGroup{}, nil
+	default:
+		return disallowAll(), nil
+	}
+}
+
+// AgentToken returns the product token of a User-Agent string ("Mozilla/5.0 (...)" -> "Mozilla"),
+// which is what robots.txt User-agent lines are matched against
+func AgentToken(userAgent string) string {
+	token := strings.TrimSpace(userAgent)
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+	return token
+}
+
+// disallowAll returns rules that block every path
+func disallowAll() *robotsGroup {
+	return &robotsGroup{rules: []robotsRule{{allow: false, pattern: "/"}}}
+}
+
+// parseRobots extracts the rules that apply to agent from a robots.txt body.
+// Groups naming agent win over the "*" group; several matching groups are merged.
+func parseRobots(body []byte, agent string) *robotsGroup {
+	agent = strings.ToLower(agent)
+
+	var specific, wildcard robotsGroup
+	foundSpecific := false
+
+	var current []string // agents of the group being read
+	inRules := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			if inRules {
+				current = nil
+				inRules = false
+			}
+			current = append(current, strings.ToLower(value))
+			continue
+		}
+
+		inRules = true
+		for _, groupAgent := range current {
+			var target *robotsGroup
+			switch {
+			case groupAgent == "*":
+				target = &wildcard
+			case groupAgent == agent:
+				target = &specific
+				foundSpecific = true
+			default:
+				continue
+			}
+
+			switch key {
+			case "allow", "disallow":
+				if value != "" {
+					target.rules = append(target.rules, robotsRule{allow: key == "allow", pattern: value})
+				}
+			case "crawl-delay":
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+					target.crawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+	}
+
+	if foundSpecific {
+		return &specific
+	}
+	return &wildcard
+}
+
+// allows applies the longest matching rule to u; on a tie Allow wins
+func (g *robotsGroup) allows(u *url.URL) (bool, string) {
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	allowed := true
+	matched := ""
+	bestLength := -1
+	for _, rule := range g.rules {
+		if !robotsMatch(rule.pattern, path) {
+			continue
+		}
+		length := len(rule.pattern)
+		if length > bestLength || (length == bestLength && rule.allow) {
+			bestLength = length
+			allowed = rule.allow
+			matched = rule.pattern
+		}
+	}
+
+	return allowed, matched
+}
+
+// robotsMatch matches a robots.txt path pattern supporting '*' wildcards and a trailing '$' anchor
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = strings.TrimSuffix(pattern, "$")
+	}
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+
+	for i := 1; i < len(parts); i++ {
+		if i == len(parts)-1 && anchored {
+			return strings.HasSuffix(rest, parts[i])
+		}
+		index := strings.Index(rest, parts[i])
+		if index < 0 {
+			return false
+		}
+		rest = rest[index+len(parts[i]):]
+	}
+
+	return !anchored || rest == ""
+}
@@ -0,0 +1,129 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRobotsMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/", "/jobs/search", true},
+		{"/jobs/", "/jobs/search", true},
+		{"/jobs/", "/jobs", false},
+		{"/search/", "/jobs/search", false},
+		{"/*/people/", "/company/nusantara-tech/people/", true},
+		{"/*/people/", "/company/nusantara-tech/about/", false},
+		{"/*.json$", "/data/jobs.json", true},
+		{"/*.json$", "/data/jobs.json?page=2", false},
+		{"/jobs$", "/jobs", true},
+		{"/jobs$", "/jobs/view", false},
+		{"/jobs/*&start=", "/jobs/search?keywords=go&start=25", true},
+		{"/jobs/*?start=", "/jobs/search?keywords=go&start=25", false},
+	}
+
+	for _, tt := range tests {
+		if got := robotsMatch(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("robotsMatch(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseRobots(t *testing.T) {
+	const body = `# LinkedIn-style robots.txt
+User-agent: Googlebot
+Disallow: /
+
+User-agent: IndoJobScraper
+User-agent: OtherBot
+Disallow: /company/*/people/
+Allow: /jobs/
+Crawl-delay: 2.5
+
+User-agent: *
+Disallow: /search/
+Disallow: /jobs/view/
+Allow: /jobs/view/$
+Crawl-delay: 1
+`
+
+	tests := []struct {
+		agent string
+		path  string
+		allow bool
+		delay time.Duration
+	}{
+		{"IndoJobScraper", "/company/nusantara-tech/people/", false, 2500 * time.Millisecond},
+		{"indojobscraper", "/company/nusantara-tech/about/", true, 2500 * time.Millisecond},
+		{"IndoJobScraper", "/search/results", true, 2500 * time.Millisecond}, // the specific group replaces "*"
+		{"Mozilla", "/search/results", false, time.Second},
+		{"Mozilla", "/jobs/view/3812345678/", false, time.Second},
+		{"Mozilla", "/jobs/view/", true, time.Second}, // equal length: Allow wins
+		{"Mozilla", "/jobs/search?keywords=go", true, time.Second},
+		{"Googlebot", "/jobs/search", false, 0},
+	}
+
+	for _, tt := range tests {
+		group := parseRobots([]byte(body), tt.agent)
+		u, err := url.Parse("https://www.linkedin.com" + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed, rule := group.allows(u); allowed != tt.allow {
+			t.Errorf("parseRobots(%s).allows(%q) = %v (rule %q), want %v", tt.agent, tt.path, allowed, rule, tt.allow)
+		}
+		if group.crawlDelay != tt.delay {
+			t.Errorf("parseRobots(%s).crawlDelay = %v, want %v", tt.agent, group.crawlDelay, tt.delay)
+		}
+	}
+}
+
+func TestAgentToken(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64)", "Mozilla"},
+		{"IndoJobScraper/1.0", "IndoJobScraper"},
+		{"IndoJobScraper", "IndoJobScraper"},
+		{" curl 8.0", "curl"},
+	}
+
+	for _, tt := range tests {
+		if got := AgentToken(tt.userAgent); got != tt.want {
+			t.Errorf("AgentToken(%q) = %q, want %q", tt.userAgent, got, tt.want)
+		}
+	}
+}
+
+func TestRobotsNotRecorded(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewRecorder(&pageFetcher{}, dir); err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	robots := NewRobotsFetcher(replayer, "Mozilla", nil)
+	for _, url := range []string{"https://www.linkedin.com/jobs/search", "https://www.linkedin.com/company/berlin-cloud"} {
+		_, err := robots.Fetch(context.Background(), url)
+		var notRecorded *NotRecordedError
+		if !errors.As(err, &notRecorded) || notRecorded.URL != "https://www.linkedin.com/robots.txt" {
+			t.Errorf("Fetch(%s) err = %v, want *NotRecordedError for robots.txt", url, err)
+		}
+	}
+	if misses := replayer.Misses(); len(misses) != 1 {
+		t.Errorf("Misses() = %v, want robots.txt looked up once", misses)
+	}
+	if disallowed := robots.Disallowed(); len(disallowed) != 0 {
+		t.Errorf("Disallowed() = %v, want nothing blocked by a robots.txt that was never recorded", disallowed)
+	}
+}
@@ -0,0 +1,243 @@
+package geo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Place is a location parsed into its parts. Names are the gazetteer's English forms.
+type Place struct {
+	City        string `json:"city,omitempty"`
+	Region      string `json:"region,omitempty"`
+	Country     string `json:"country,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+}
+
+// IsZero reports whether nothing was recognized
+func (p Place) IsZero() bool {
+	return p == Place{}
+}
+
+// String joins the known parts as "City, Region, Country"
+func (p Place) String() string {
+	var parts []string
+	for _, part := range []string{p.City, p.Region, p.Country} {
+		if part != "" && (len(parts) == 0 || parts[len(parts)-1] != part) {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// entry is one gazetteer line
+type entry struct {
+	kind    string // "country", "region" or "city"
+	country string
+	region  string // for countries: the ISO code
+	name    string
+}
+
+// Gazetteer resolves place names and their local spellings for the countries we search in
+type Gazetteer struct {
+	names     map[string][]entry // normalized name or alias -> places
+	countries map[string]entry   // country name -> country entry
+}
+
+// LoadGazetteer reads a gazetteer file (see data/gazetteer.txt for the format)
+func LoadGazetteer(filename string) (*Gazetteer, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	g := &Gazetteer{
+		names:     make(map[string][]entry),
+		countries: make(map[string]entry),
+	}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "|")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("%s:%d: expected 5 fields separated by |, got %d", filename, lineNumber, len(fields))
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		e := entry{kind: fields[0], country: fields[1], region: fields[2], name: fields[3]}
+		if e.kind != "country" && e.kind != "region" && e.kind != "city" {
+			return nil, fmt.Errorf("%s:%d: unknown kind %q", filename, lineNumber, e.kind)
+		}
+		if e.kind == "country" {
+			g.countries[e.country] = e
+		}
+
+		g.add(e.name, e)
+		for _, alias := range strings.Split(fields[4], ",") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				g.add(alias, e)
+			}
+		}
+	}
+
+	return g, scanner.Err()
+}
+
+// add indexes e under name
+func (g *Gazetteer) add(name string, e entry) {
+	key := normalize(name)
+	g.names[key] = append(g.names[key], e)
+}
+
+// Stats returns the number of names and countries loaded
+func (g *Gazetteer) Stats() map[string]int {
+	return map[string]int{
+		"names":     len(g.names),
+		"countries": len(g.countries),
+	}
+}
+
+// normalize lower-cases a name and collapses its whitespace
+func normalize(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// CityName returns the gazetteer's name for a city given in any known spelling
+// ("München" gives "Munich"), or the input unchanged when it is not a known city
+func (g *Gazetteer) CityName(name string) string {
+	for _, e := range g.names[normalize(name)] {
+		if e.kind == "city" {
+			return e.name
+		}
+	}
+	return strings.TrimSpace(name)
+}
+
+var (
+	// segmentSeparators split a location text into its parts
+	segmentSeparators = regexp.MustCompile(`\s*(?:,|·|•|\||\n|\s-\s)\s*`)
+	// areaAffixes are LinkedIn's wrappers around metro areas ("Greater Munich Metropolitan Area")
+	areaPrefix = regexp.MustCompile(`(?i)^(?:greater|großraum|metropolregion|regio)\s+`)
+	areaSuffix = regexp.MustCompile(`(?i)\s+(?:metropolitan area|metro area|area|region|und umgebung|e\.o\.|en omgeving)$`)
+)
+
+// Parse splits a location text such as "Munich, Bavaria, Germany (Hybrid)" into a Place
+// and a workplace type, and returns the text without posted-time and applicant noise
+func (g *Gazetteer) Parse(text string) (Place, WorkplaceType, string) {
+	workplace := DetectWorkplace(text)
+	cleaned := Clean(text)
+
+	var segments []string
+	for _, segment := range segmentSeparators.Split(cleaned, -1) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	candidates := make([][]entry, len(segments))
+	for i, segment := range segments {
+		candidates[i] = g.lookup(segment)
+	}
+
+	var place Place
+	countryAt, cityAt := -1, -1
+
+	// Country: usually the last part
+	for i := len(segments) - 1; i >= 0 && countryAt < 0; i-- {
+		for _, e := range candidates[i] {
+			if e.kind == "country" {
+				place.Country, place.CountryCode = e.country, e.region
+				countryAt = i
+				break
+			}
+		}
+	}
+
+	// City: the first part naming a city in that country
+	for i := range segments {
+		if i == countryAt && len(segments) > 1 {
+			continue
+		}
+		if e, ok := pick(candidates[i], "city", place.Country); ok {
+			place.City, place.Region = e.name, e.region
+			if place.Country == "" {
+				place.Country = e.country
+			}
+			cityAt = i
+			break
+		}
+	}
+
+	// Region: a part naming a region, which wins over the city's default region
+	for i := range segments {
+		if i == countryAt || i == cityAt {
+			continue
+		}
+		if e, ok := pick(candidates[i], "region", place.Country); ok {
+			place.Region = e.name
+			if place.Country == "" {
+				place.Country = e.country
+			}
+			break
+		}
+	}
+
+	if place.Country != "" && place.CountryCode == "" {
+		place.CountryCode = g.countries[place.Country].region
+	}
+
+	return place, workplace, cleaned
+}
+
+// lookup finds the places a segment names, trying it as-is, without metro-area wrappers,
+// and then its longest leading run of words
+func (g *Gazetteer) lookup(segment string) []entry {
+	if found := g.names[normalize(segment)]; found != nil {
+		return found
+	}
+
+	stripped := areaSuffix.ReplaceAllString(areaPrefix.ReplaceAllString(segment, ""), "")
+	if found := g.names[normalize(stripped)]; found != nil {
+		return found
+	}
+
+	fields := strings.Fields(stripped)
+	for n := len(fields) - 1; n > 0; n-- {
+		if found := g.names[normalize(strings.Join(fields[:n], " "))]; found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// pick returns the first candidate of the given kind, preferring one in country
+func pick(candidates []entry, kind, country string) (entry, bool) {
+	var fallback *entry
+	for i, e := range candidates {
+		if e.kind != kind {
+			continue
+		}
+		if country == "" || e.country == country {
+			return e, true
+		}
+		if fallback == nil {
+			fallback = &candidates[i]
+		}
+	}
+	if fallback != nil && country == "" {
+		return *fallback, true
+	}
+	return entry{}, false
+}
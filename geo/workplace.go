@@ -0,0 +1,89 @@
+package geo
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WorkplaceType is where the work happens, as LinkedIn labels it
+type WorkplaceType string
+
+const (
+	Remote  WorkplaceType = "remote"
+	Hybrid  WorkplaceType = "hybrid"
+	OnSite  WorkplaceType = "on-site"
+	Unknown WorkplaceType = ""
+)
+
+var (
+	// workplacePatterns recognize workplace labels in English, German and Dutch
+	hybridPattern = regexp.MustCompile(`(?i)\b(?:hybrid|hybride)\b`)
+	remotePattern = regexp.MustCompile(`(?i)\b(?:remote|fernarbeit|op afstand|thuiswerk(?:en)?|work from home|wfh)\b`)
+	onSitePattern = regexp.MustCompile(`(?i)\b(?:on-site|onsite|on site|in-office|in office|vor ort|op locatie)\b`)
+
+	// workplaceLabel removes workplace labels, with or without parentheses, from a location
+	workplaceLabel = regexp.MustCompile(`(?i)\(\s*(?:hybrid|hybride|remote|on-site|onsite|on site|vor ort|op locatie)\s*\)|\b(?:hybrid|hybride|remote|on-site|onsite|vor ort|op locatie)\b`)
+
+	// noisePatterns match posted-time, applicant and promotion text that location selectors pick up
+	noisePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b\d+\s+(?:seconds?|minutes?|hours?|days?|weeks?|months?|years?)\s+ago\b`),
+		regexp.MustCompile(`(?i)\bvor\s+(?:\d+|einer|einem)\s+(?:sekunden?|minuten?|stunden?|tag(?:en)?|wochen?|monat(?:en)?|jahr(?:en)?)\b`),
+		regexp.MustCompile(`(?i)\b\d+\s+(?:seconden|minuten|uur|dagen?|weken|week|maanden?|jaar)\s+geleden\b`),
+		regexp.MustCompile(`(?i)(?:\b(?:over|mehr als|meer dan)\s+|über\s+)?\d[\d,.]*\+?\s+(?:applicants?|bewerber(?:innen|:innen)?|bewerbungen|sollicitanten)\b`),
+		regexp.MustCompile(`(?i)\b(?:just now|today|yesterday|reposted|promoted|actively recruiting|easy apply|be an early applicant|gerade eben|heute|gestern|beworben)\b`),
+		// the German "Neu" badge in brackets or between dashes; place names such as Neu-Ulm keep their Neu
+		regexp.MustCompile(`(?i)[(\[]\s*neu\s*[)\]]|(?:^|\s)[-–—]\s*neu\s*[-–—](?:\s|$)`),
+	}
+
+	// badgeSegments are badges that make up a whole comma-separated segment of a location
+	badgeSegments = map[string]bool{"neu": true, "new": true}
+)
+
+// DetectWorkplace finds a workplace label in text. Hybrid wins when both hybrid and
+// remote are mentioned, and on-site is only reported when neither is.
+func DetectWorkplace(text string) WorkplaceType {
+	switch {
+	case hybridPattern.MatchString(text):
+		return Hybrid
+	case remotePattern.MatchString(text):
+		return Remote
+	case onSitePattern.MatchString(text):
+		return OnSite
+	}
+	return Unknown
+}
+
+// ParseWorkplace reads a workplace type given by the user or a search filter
+func ParseWorkplace(value string) WorkplaceType {
+	return DetectWorkplace(strings.TrimSpace(value))
+}
+
+// Clean strips posted-time, applicant counts and workplace labels from a location text
+func Clean(text string) string {
+	for _, pattern := range noisePatterns {
+		text = pattern.ReplaceAllString(text, " ")
+	}
+	text = workplaceLabel.ReplaceAllString(text, " ")
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(dropBadgeSegments(line)), " ")
+		line = strings.Trim(line, " ,·•|-()")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, ", ")
+}
+
+// dropBadgeSegments removes the comma-separated segments of a line that are only a badge
+func dropBadgeSegments(line string) string {
+	segments := strings.Split(line, ",")
+	kept := segments[:0]
+	for _, segment := range segments {
+		if !badgeSegments[strings.ToLower(strings.Trim(segment, " ·•|"))] {
+			kept = append(kept, segment)
+		}
+	}
+	return strings.Join(kept, ",")
+}
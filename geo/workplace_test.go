@@ -0,0 +1,38 @@
+package geo
+
+import "testing"
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"Berlin, Berlin, Germany", "Berlin, Berlin, Germany"},
+		{"Munich, Bavaria, Germany (Hybrid)", "Munich, Bavaria, Germany"},
+		{"Berlin, Germany 2 days ago 35 applicants", "Berlin, Germany"},
+		{"Hamburg, Germany\nvor 3 Tagen\nÜber 200 Bewerber", "Hamburg, Germany"},
+		{"Neu-Ulm, Bavaria, Germany", "Neu-Ulm, Bavaria, Germany"},
+		{"Neu-Ulm, Bavaria, Germany\nNeu", "Neu-Ulm, Bavaria, Germany"},
+		{"Neu, Neu-Ulm, Bavaria, Germany", "Neu-Ulm, Bavaria, Germany"},
+		{"Neu-Ulm, Bavaria, Germany (Neu)", "Neu-Ulm, Bavaria, Germany"},
+		{"Neu-Ulm, Bavaria, Germany - Neu -", "Neu-Ulm, Bavaria, Germany"},
+	}
+
+	for _, tt := range tests {
+		if got := Clean(tt.text); got != tt.want {
+			t.Errorf("Clean(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseNeuUlm(t *testing.T) {
+	places, err := LoadGazetteer("../data/gazetteer.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	place, _, _ := places.Parse("Neu-Ulm, Bavaria, Germany\nNeu")
+	want := Place{City: "Neu-Ulm", Region: "Bavaria", Country: "Germany", CountryCode: "DE"}
+	if place != want {
+		t.Errorf("Parse = %+v, want %+v", place, want)
+	}
+}
@@ -2,9 +2,9 @@ module github.com/goesbams/linkedin-job-scraper
 
 go 1.21
 
-require github.com/PuerkitoBio/goquery v1.8.1
-
 require (
-	github.com/andybalholm/cascadia v1.3.1 // indirect
-	golang.org/x/net v0.7.0 // indirect
+	github.com/PuerkitoBio/goquery v1.8.1
+	github.com/andybalholm/cascadia v1.3.1
 )
+
+require golang.org/x/net v0.7.0 // indirect
@@ -0,0 +1,174 @@
+package history
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Posting is a job posting as remembered between runs
+type Posting struct {
+	ID        string    `json:"id"` // job ID, or the canonical job URL when there is none
+	Title     string    `json:"title"`
+	Company   string    `json:"company"`
+	JobURL    string    `json:"job_url"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Run is one entry in the run log of a search
+type Run struct {
+	At          time.Time `json:"at"`
+	Found       int       `json:"found"`
+	Appeared    int       `json:"appeared"`
+	Disappeared int       `json:"disappeared"`
+	Complete    bool      `json:"complete"` // false when the search stopped early
+}
+
+// Report compares one run with the runs before it
+type Report struct {
+	Search      string    `json:"search"`
+	FirstRun    bool      `json:"first_run"`
+	PreviousRun time.Time `json:"previous_run,omitempty"`
+	Appeared    []Posting `json:"appeared"`
+	Disappeared []Posting `json:"disappeared"`
+}
+
+// IsNew reports whether the posting with id appeared in this run
+func (r Report) IsNew(id string) bool {
+	for _, p := range r.Appeared {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// History remembers which postings a search listed, so each run can report what is new
+// and what is gone. It is kept as one JSON file per search.
+type History struct {
+	Search   string             `json:"search"`
+	Postings map[string]Posting `json:"postings"` // listed at the last run, by ID
+	Runs     []Run              `json:"runs"`
+
+	path string
+}
+
+// maxRuns caps the run log kept per search
+const maxRuns = 100
+
+// Open loads the history of the named search from dir, or starts an empty one
+func Open(dir, search string) (*History, error) {
+	h := &History{
+		Search:   search,
+		Postings: make(map[string]Posting),
+		path:     filepath.Join(dir, FileName(search)),
+	}
+
+	data, err := os.ReadFile(h.path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run history: %v", err)
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("invalid run history %s: %v", h.path, err)
+	}
+	if h.Postings == nil {
+		h.Postings = make(map[string]Posting)
+	}
+	return h, nil
+}
+
+// unsafeChars are replaced when a search name becomes a file name
+var unsafeChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// FileName turns a search name into a file name, shortening long names with a hash
+func FileName(search string) string {
+	name := strings.Trim(unsafeChars.ReplaceAllString(strings.ToLower(search), "_"), "_")
+	if len(name) > 60 {
+		name = fmt.Sprintf("%s_%x", name[:50], sha1.Sum([]byte(search)))[:61]
+	}
+	if name == "" {
+		name = "search"
+	}
+	return name + ".json"
+}
+
+// Update records the postings found by this run and reports what changed since the last one.
+// When complete is false the search stopped early, so postings missing from current are
+// kept and not reported as disappeared.
+func (h *History) Update(current []Posting, complete bool, now time.Time) Report {
+	report := Report{Search: h.Search, FirstRun: len(h.Runs) == 0}
+	if len(h.Runs) > 0 {
+		report.PreviousRun = h.Runs[len(h.Runs)-1].At
+	}
+
+	listed := make(map[string]bool)
+	for _, posting := range current {
+		listed[posting.ID] = true
+		if known, ok := h.Postings[posting.ID]; ok {
+			posting.FirstSeen = known.FirstSeen
+		} else {
+			posting.FirstSeen = now
+			report.Appeared = append(report.Appeared, posting)
+		}
+		posting.LastSeen = now
+		h.Postings[posting.ID] = posting
+	}
+
+	if complete {
+		for id, posting := range h.Postings {
+			if !listed[id] {
+				report.Disappeared = append(report.Disappeared, posting)
+				delete(h.Postings, id)
+			}
+		}
+		sort.Slice(report.Disappeared, func(i, j int) bool {
+			return report.Disappeared[i].FirstSeen.Before(report.Disappeared[j].FirstSeen)
+		})
+	}
+
+	h.Runs = append(h.Runs, Run{
+		At:          now,
+		Found:       len(current),
+		Appeared:    len(report.Appeared),
+		Disappeared: len(report.Disappeared),
+		Complete:    complete,
+	})
+	if len(h.Runs) > maxRuns {
+		h.Runs = h.Runs[len(h.Runs)-maxRuns:]
+	}
+
+	return report
+}
+
+// Save writes the history atomically
+func (h *History) Save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run history: %v", err)
+	}
+
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write run history: %v", err)
+	}
+	return os.Rename(tmp, h.path)
+}
+
+// Path returns the file the history is kept in
+func (h *History) Path() string {
+	return h.path
+}
@@ -0,0 +1,139 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func postings(ids ...string) []Posting {
+	var list []Posting
+	for _, id := range ids {
+		list = append(list, Posting{ID: id, Title: "Go Developer " + id, JobURL: "https://www.linkedin.com/jobs/view/" + id + "/"})
+	}
+	return list
+}
+
+func ids(list []Posting) []string {
+	var result []string
+	for _, p := range list {
+		result = append(result, p.ID)
+	}
+	return result
+}
+
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestUpdate(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	runs := []struct {
+		listed      []string
+		complete    bool
+		appeared    []string
+		disappeared []string
+		known       int // postings remembered after the run
+	}{
+		{listed: []string{"1", "2", "3"}, complete: true, appeared: []string{"1", "2", "3"}, known: 3},
+		{listed: []string{"2", "3", "4"}, complete: true, appeared: []string{"4"}, disappeared: []string{"1"}, known: 3},
+		{listed: []string{"2"}, complete: false, known: 3}, // stopped early: 3 and 4 may still be listed
+		{listed: []string{"2", "4"}, complete: true, disappeared: []string{"3"}, known: 2},
+		{listed: []string{"1", "2", "4"}, complete: true, appeared: []string{"1"}, known: 3}, // 1 came back
+	}
+
+	h := &History{Search: "golang-germany", Postings: make(map[string]Posting)}
+	for i, run := range runs {
+		now := start.Add(time.Duration(i) * 24 * time.Hour)
+		report := h.Update(postings(run.listed...), run.complete, now)
+
+		if report.FirstRun != (i == 0) {
+			t.Errorf("run %d: FirstRun = %v", i, report.FirstRun)
+		}
+		if i > 0 && !report.PreviousRun.Equal(now.Add(-24*time.Hour)) {
+			t.Errorf("run %d: PreviousRun = %v, want the day before", i, report.PreviousRun)
+		}
+		if got := ids(report.Appeared); !sameIDs(got, run.appeared) {
+			t.Errorf("run %d: appeared %v, want %v", i, got, run.appeared)
+		}
+		if got := ids(report.Disappeared); !sameIDs(got, run.disappeared) {
+			t.Errorf("run %d: disappeared %v, want %v", i, got, run.disappeared)
+		}
+		if len(h.Postings) != run.known {
+			t.Errorf("run %d: remembers %d postings, want %d", i, len(h.Postings), run.known)
+		}
+		for _, id := range run.appeared {
+			if !report.IsNew(id) {
+				t.Errorf("run %d: IsNew(%q) = false for an appeared posting", i, id)
+			}
+		}
+
+		last := h.Runs[len(h.Runs)-1]
+		if last.Found != len(run.listed) || last.Complete != run.complete {
+			t.Errorf("run %d: logged %+v", i, last)
+		}
+	}
+
+	if first := h.Postings["2"].FirstSeen; !first.Equal(start) {
+		t.Errorf("posting 2 first seen %v, want the first run", first)
+	}
+	if last := h.Postings["2"].LastSeen; !last.Equal(start.Add(4 * 24 * time.Hour)) {
+		t.Errorf("posting 2 last seen %v, want the last run", last)
+	}
+}
+
+func TestOpenSave(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	h, err := Open(dir, "Golang Germany")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Update(postings("1", "2"), true, now)
+	if err := h.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(dir, "Golang Germany")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := reopened.Update(postings("2"), true, now.Add(time.Hour))
+	if report.FirstRun || !report.PreviousRun.Equal(now) {
+		t.Errorf("reopened history forgot the first run: %+v", report)
+	}
+	if got := ids(report.Disappeared); !sameIDs(got, []string{"1"}) {
+		t.Errorf("disappeared %v, want [1]", got)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"golang-germany", "golang_germany.json"},
+		{"Golang Developer / Berlin", "golang_developer_berlin.json"},
+		{"!!!", "search.json"},
+	}
+
+	for _, tt := range tests {
+		if got := FileName(tt.search); got != tt.want {
+			t.Errorf("FileName(%q) = %q, want %q", tt.search, got, tt.want)
+		}
+	}
+
+	long := FileName("golang developer in berlin munich hamburg cologne frankfurt stuttgart")
+	if len(long) != len("x.json")+60 {
+		t.Errorf("FileName of a long search = %q (%d bytes), want a 60 byte name", long, len(long))
+	}
+}
@@ -0,0 +1,150 @@
+package linkedinurl
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Base is the origin canonical URLs are built on
+const Base = "https://www.linkedin.com"
+
+// trackingParams are query parameters LinkedIn adds for click tracking; they never change the page
+var trackingParams = map[string]bool{
+	"refid":              true,
+	"trackingid":         true,
+	"trk":                true,
+	"trkinfo":            true,
+	"lipi":               true,
+	"licu":               true,
+	"position":           true,
+	"pagenum":            true,
+	"originalsubdomain":  true,
+	"original_referer":   true,
+	"midtoken":           true,
+	"midsig":             true,
+	"eba":                true,
+	"ebp":                true,
+	"recommendedflavor":  true,
+	"trackingchannel":    true,
+	"alternatechannel":   true,
+	"upsellorderorigin":  true,
+	"eligibleforpremium": true,
+}
+
+var (
+	// viewIDPattern matches /jobs/view/<id> and /jobs/view/<slug>-<id>
+	viewIDPattern = regexp.MustCompile(`/jobs/view/(?:[^/?#]*-)?(\d+)`)
+	// urnIDPattern matches urn:li:jobPosting:<id> and its fs_normalized variants
+	urnIDPattern = regexp.MustCompile(`(?i)jobPosting:(\d+)`)
+	// digitsPattern matches a bare numeric ID such as data-job-id values
+	digitsPattern = regexp.MustCompile(`^\d+$`)
+)
+
+// Absolute resolves a LinkedIn href against the site origin
+func Absolute(href string) string {
+	href = strings.TrimSpace(href)
+	if strings.HasPrefix(href, "http") {
+		return href
+	}
+	if strings.HasPrefix(href, "//") {
+		return "https:" + href
+	}
+	if strings.HasPrefix(href, "/") {
+		return Base + href
+	}
+	return href
+}
+
+// JobIDFromURL returns the numeric job ID in a /jobs/view/ URL or a currentJobId
+// parameter, or "" if the URL does not name a job
+func JobIDFromURL(rawURL string) string {
+	if match := viewIDPattern.FindStringSubmatch(rawURL); match != nil {
+		return match[1]
+	}
+	if u, err := url.Parse(rawURL); err == nil {
+		if id := u.Query().Get("currentJobId"); digitsPattern.MatchString(id) {
+			return id
+		}
+	}
+	return ""
+}
+
+// JobIDFromAttr returns the job ID held by a data-entity-urn, data-job-id or
+// similar attribute value, or "" if it holds none
+func JobIDFromAttr(value string) string {
+	value = strings.TrimSpace(value)
+	if digitsPattern.MatchString(value) {
+		return value
+	}
+	if match := urnIDPattern.FindStringSubmatch(value); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// JobURL returns the canonical posting URL for a job ID
+func JobURL(id string) string {
+	return Base + "/jobs/view/" + id + "/"
+}
+
+// CanonicalJobURL rewrites a job link to its canonical form: the /jobs/view/<id>/
+// URL when the link names a job, otherwise the link without tracking parameters
+func CanonicalJobURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	if id := JobIDFromURL(rawURL); id != "" {
+		return JobURL(id)
+	}
+	return StripTracking(rawURL)
+}
+
+// CanonicalCompanyURL rewrites a company link to https://www.linkedin.com/company/<slug>
+// with the slug lower-cased, dropping sub-pages such as /people/ and any query string.
+// Links that are not company pages only lose their tracking parameters.
+func CanonicalCompanyURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(Absolute(rawURL))
+	if err != nil {
+		return rawURL
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, part := range parts {
+		if part == "company" && i+1 < len(parts) && parts[i+1] != "" {
+			return Base + "/company/" + strings.ToLower(parts[i+1])
+		}
+	}
+
+	return StripTracking(rawURL)
+}
+
+// StripTracking removes tracking parameters and the fragment from a URL and moves
+// country subdomains (de.linkedin.com) onto www.linkedin.com
+func StripTracking(rawURL string) string {
+	u, err := url.Parse(Absolute(rawURL))
+	if err != nil {
+		return rawURL
+	}
+
+	if strings.HasSuffix(u.Host, ".linkedin.com") || u.Host == "linkedin.com" {
+		u.Scheme = "https"
+		u.Host = "www.linkedin.com"
+	}
+	u.Fragment = ""
+
+	query := u.Query()
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,83 @@
+package linkedinurl
+
+import "testing"
+
+func TestJobIDFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.linkedin.com/jobs/view/3812345678/", "3812345678"},
+		{"https://de.linkedin.com/jobs/view/senior-go-developer-at-nusantara-tech-3812345678?refId=abc&trk=public_jobs", "3812345678"},
+		{"/jobs/view/3812345678", "3812345678"},
+		{"https://www.linkedin.com/jobs/search?keywords=go&currentJobId=3812349999", "3812349999"},
+		{"https://www.linkedin.com/jobs/search?currentJobId=abc", ""},
+		{"https://www.linkedin.com/company/nusantara-tech", ""},
+	}
+
+	for _, tt := range tests {
+		if got := JobIDFromURL(tt.url); got != tt.want {
+			t.Errorf("JobIDFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestJobIDFromAttr(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"3812345678", "3812345678"},
+		{" 3812345678 ", "3812345678"},
+		{"urn:li:jobPosting:3812345678", "3812345678"},
+		{"urn:li:fs_normalized_jobPosting:3812345678", "3812345678"},
+		{"urn:li:company:12345", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := JobIDFromAttr(tt.value); got != tt.want {
+			t.Errorf("JobIDFromAttr(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalJobURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://de.linkedin.com/jobs/view/senior-go-developer-3812345678?refId=abc&trackingId=xyz", "https://www.linkedin.com/jobs/view/3812345678/"},
+		{"/jobs/view/3812345678/?trk=public_jobs_topcard", "https://www.linkedin.com/jobs/view/3812345678/"},
+		{"https://www.linkedin.com/jobs/search?currentJobId=3812349999&keywords=go", "https://www.linkedin.com/jobs/view/3812349999/"},
+		{"https://de.linkedin.com/jobs/collections/recommended?trk=nav#top", "https://www.linkedin.com/jobs/collections/recommended"},
+		{"https://careers.example.com/go?trk=x&team=platform", "https://careers.example.com/go?team=platform"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalJobURL(tt.url); got != tt.want {
+			t.Errorf("CanonicalJobURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalCompanyURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.linkedin.com/company/nusantara-tech", "https://www.linkedin.com/company/nusantara-tech"},
+		{"https://de.linkedin.com/company/Nusantara-Tech/people/?trk=public_jobs_topcard-org-name", "https://www.linkedin.com/company/nusantara-tech"},
+		{"/company/berlin-cloud/about/", "https://www.linkedin.com/company/berlin-cloud"},
+		{"  https://www.linkedin.com/company/berlin-cloud?originalSubdomain=de  ", "https://www.linkedin.com/company/berlin-cloud"},
+		{"https://www.linkedin.com/school/tu-berlin/?trk=x", "https://www.linkedin.com/school/tu-berlin/"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalCompanyURL(tt.url); got != tt.want {
+			t.Errorf("CanonicalCompanyURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
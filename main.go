@@ -1,32 +1,100 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/goesbams/linkedin-job-scraper/analyze"
+	"github.com/goesbams/linkedin-job-scraper/companycache"
+	"github.com/goesbams/linkedin-job-scraper/fetcher"
+	"github.com/goesbams/linkedin-job-scraper/geo"
+	"github.com/goesbams/linkedin-job-scraper/history"
+	"github.com/goesbams/linkedin-job-scraper/linkedinurl"
 	"github.com/goesbams/linkedin-job-scraper/names"
+	"github.com/goesbams/linkedin-job-scraper/pageclass"
+	"github.com/goesbams/linkedin-job-scraper/posted"
+	"github.com/goesbams/linkedin-job-scraper/salary"
+	"github.com/goesbams/linkedin-job-scraper/selectors"
+	"github.com/goesbams/linkedin-job-scraper/structured"
 )
 
 // Job represents a job posting
 type Job struct {
-	Title               string     `json:"title"`
-	Company             string     `json:"company"`
-	Location            string     `json:"location"`
-	JobURL              string     `json:"job_url"`
-	CompanyURL          string     `json:"company_url"`
-	HasIndonesian       bool       `json:"has_indonesian"`
-	IndonesianEmployees []Employee `json:"indonesian_employees"`
-	EmployeeCount       int        `json:"employee_count"`
-	CheckDuration       string     `json:"check_duration"`
+	ID                  string                  `json:"id,omitempty"` // LinkedIn job ID
+	Title               string                  `json:"title"`
+	Company             string                  `json:"company"`
+	Location            string                  `json:"location"` // location text without posted-time and applicant noise
+	Place               *geo.Place              `json:"place,omitempty"`
+	WorkplaceType       geo.WorkplaceType       `json:"workplace_type,omitempty"`   // remote, hybrid or on-site
+	PostedAt            *time.Time              `json:"posted_at,omitempty"`        // from the card or posting page, relative times counted from the fetch
+	PostedPrecision     posted.Precision        `json:"posted_precision,omitempty"` // how exactly PostedAt is known: exact, minute, hour, day, week, month or year
+	Salary              *salary.Salary          `json:"salary,omitempty"`           // from the card, the posting page or the description
+	Queries             []QueryRef              `json:"queries,omitempty"`          // the searches that found this job
+	New                 bool                    `json:"new,omitempty"`              // not listed by earlier runs of the same search
+	JobURL              string                  `json:"job_url"`
+	CompanyURL          string                  `json:"company_url"`
+	HasIndonesian       bool                    `json:"has_indonesian"`
+	IndonesianEmployees []Employee              `json:"indonesian_employees"`
+	EmployeeCount       int                     `json:"employee_count"`
+	CheckDuration       string                  `json:"check_duration"`
+	CheckError          string                  `json:"check_error,omitempty"`
+	CheckPageKind       pageclass.Kind          `json:"check_page_kind,omitempty"` // what LinkedIn served instead of the company page, when the check hit a wall
+	CompanyCache        *CacheInfo              `json:"company_cache,omitempty"`
+	Details             *JobDetails             `json:"details,omitempty"`
+	Visa                *analyze.VisaResult     `json:"visa,omitempty"`      // from the description, needs --details
+	Languages           *analyze.LanguageResult `json:"languages,omitempty"` // from the description, needs --details
+}
+
+// JobDetails holds what the posting page adds to a search card (filled by --details)
+type JobDetails struct {
+	Description    string `json:"description,omitempty"`
+	SeniorityLevel string `json:"seniority_level,omitempty"`
+	EmploymentType string `json:"employment_type,omitempty"`
+	JobFunction    string `json:"job_function,omitempty"`
+	Industries     string `json:"industries,omitempty"`
+	PostedDate     string `json:"posted_date,omitempty"` // as shown on the page, e.g. "2 days ago", or datePosted from structured data
+	Applicants     string `json:"applicants,omitempty"`  // as shown on the page, e.g. "Over 200 applicants"
+	Salary         string `json:"salary,omitempty"`      // as shown in the page's pay box, e.g. "€60K/yr - €75K/yr"
+	ApplicantCount int    `json:"applicant_count,omitempty"`
+	FetchError     string `json:"fetch_error,omitempty"`
+	Source         string `json:"source,omitempty"` // "json-ld" or "hydration" when the page embedded the posting as structured data
+
+	posting *structured.Posting // the embedded posting, applied to the job by EnrichJobs
+}
+
+// QueryRef names one keywords/location search of a run
+type QueryRef struct {
+	Keywords string `json:"keywords"`
+	Location string `json:"location"`
+}
+
+// String formats the search as "keywords in location"
+func (r QueryRef) String() string {
+	return fmt.Sprintf("%s in %s", r.Keywords, r.Location)
+}
+
+// CacheInfo tells whether a company result was checked fresh or reused from the company cache
+type CacheInfo struct {
+	Status    string `json:"status"` // "fresh" or "cached"
+	CheckedAt string `json:"checked_at"`
+	Age       string `json:"age"`
 }
 
 // Employee represents an Indonesian employee found
@@ -39,14 +107,21 @@ type Employee struct {
 
 // LinkedInScraper handles the scraping logic with enhanced debugging
 type LinkedInScraper struct {
-	client *http.Client
-	delay  time.Duration
-	nameDB *names.NameDB
-	debug  bool
+	fetcher     fetcher.Fetcher
+	nameDB      *names.NameDB
+	places      *geo.Gazetteer
+	selectors   *selectors.Set
+	health      *selectors.Health // which selectors matched, for drift warnings
+	debug       bool
+	concurrency int // parallel company checks in ProcessJobsWithFallback
+	companies   *companycache.Cache
+	searchStats SearchStats
+	accessOK    bool // testLinkedInAccess already ran for this scraper
 }
 
-// NewLinkedInScraper creates a new scraper instance with debug mode
-func NewLinkedInScraper() (*LinkedInScraper, error) {
+// NewLinkedInScraper creates a new scraper instance that fetches pages through f.
+// Request pacing is the fetcher's job (see fetcher.RateLimiter), so the scraper never sleeps itself.
+func NewLinkedInScraper(f fetcher.Fetcher) (*LinkedInScraper, error) {
 	// Initialize the Indonesian names database
 	nameDB, err := names.NewNameDB()
 	if err != nil {
@@ -57,19 +132,63 @@ func NewLinkedInScraper() (*LinkedInScraper, error) {
 	stats := nameDB.GetStats()
 	log.Printf("Loaded Indonesian names database: %+v", stats)
 
-	// Check for debug mode
-	debug := os.Getenv("DEBUG") == "true" || os.Getenv("SCRAPER_DEBUG") == "true"
+	// Initialize the offline gazetteer used to parse job locations
+	places, err := geo.LoadGazetteer("data/gazetteer.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gazetteer: %v", err)
+	}
+	log.Printf("Loaded location gazetteer: %+v", places.Stats())
+
+	// Built-in selectors until SetSelectors installs a selector file
+	set, err := selectors.Default()
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an on-disk store, still check each company only once per run
+	companies, err := companycache.Open("", 0, false)
+	if err != nil {
+		return nil, err
+	}
 
 	return &LinkedInScraper{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		delay:  3 * time.Second, // Increased delay to be more respectful
-		nameDB: nameDB,
-		debug:  debug,
+		fetcher:     f,
+		nameDB:      nameDB,
+		places:      places,
+		selectors:   set,
+		health:      selectors.NewHealth(set),
+		debug:       debugEnabled(),
+		concurrency: 1,
+		companies:   companies,
 	}, nil
 }
 
+// SetConcurrency sets how many companies ProcessJobsWithFallback checks in parallel
+func (s *LinkedInScraper) SetConcurrency(workers int) {
+	s.concurrency = workers
+}
+
+// SetSelectors replaces the CSS selectors used to read pages
+func (s *LinkedInScraper) SetSelectors(set *selectors.Set) {
+	s.selectors = set
+	s.health = selectors.NewHealth(set)
+}
+
+// SelectorHealth reports which selectors matched on the pages read so far
+func (s *LinkedInScraper) SelectorHealth() selectors.Report {
+	return s.health.Report()
+}
+
+// SetCompanyCache replaces the per-run company cache, e.g. with one backed by an on-disk store
+func (s *LinkedInScraper) SetCompanyCache(cache *companycache.Cache) {
+	s.companies = cache
+}
+
+// debugEnabled reports whether debug mode was requested through the environment
+func debugEnabled() bool {
+	return os.Getenv("DEBUG") == "true" || os.Getenv("SCRAPER_DEBUG") == "true"
+}
+
 // debugLog prints debug information if debug mode is enabled
 func (s *LinkedInScraper) debugLog(format string, args ...interface{}) {
 	if s.debug {
@@ -77,30 +196,20 @@ func (s *LinkedInScraper) debugLog(format string, args ...interface{}) {
 	}
 }
 
-// makeRequest makes an HTTP request with enhanced headers and debugging
-func (s *LinkedInScraper) makeRequest(url string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Enhanced headers to appear more like a real browser
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("Upgrade-Insecure-Requests", "1")
-	req.Header.Set("Sec-Fetch-Dest", "document")
-	req.Header.Set("Sec-Fetch-Mode", "navigate")
-	req.Header.Set("Sec-Fetch-Site", "none")
-	req.Header.Set("Cache-Control", "max-age=0")
-	req.Header.Set("DNT", "1")
-
+// makeRequest fetches a page through the configured fetcher with debugging
+func (s *LinkedInScraper) makeRequest(ctx context.Context, url string) (*fetcher.Response, error) {
 	s.debugLog("Making request to: %s", url)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.fetcher.Fetch(ctx, url)
 	if err != nil {
+		var notRecorded *fetcher.NotRecordedError
+		if errors.As(err, &notRecorded) {
+			log.Printf("❌ REPLAY MISS: %s was not recorded in this session", url)
+		}
+		var disallowed *fetcher.DisallowedError
+		if errors.As(err, &disallowed) {
+			log.Printf("🚫 Skipping %s: disallowed by robots.txt (Disallow: %s)", url, disallowed.Rule)
+		}
 		return nil, err
 	}
 
@@ -109,16 +218,38 @@ func (s *LinkedInScraper) makeRequest(url string) (*http.Response, error) {
 	return resp, nil
 }
 
+// fetchedAt returns when a page was served: its Date header (kept by --record), or now
+func fetchedAt(resp *fetcher.Response) time.Time {
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		return date
+	}
+	return time.Now()
+}
+
+// stopCause returns the error that must stop the whole run, or nil if err only affects one request
+func stopCause(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if errors.Is(err, fetcher.ErrBudgetExhausted) || errors.Is(err, fetcher.ErrCircuitOpen) {
+		return err
+	}
+	var pageErr *pageclass.Error
+	if errors.As(err, &pageErr) && pageErr.Kind == pageclass.Challenge {
+		return err // every further request would get the same challenge
+	}
+	return nil
+}
+
 // testLinkedInAccess tests if LinkedIn is accessible and not blocking us
-func (s *LinkedInScraper) testLinkedInAccess() error {
+func (s *LinkedInScraper) testLinkedInAccess(ctx context.Context) error {
 	testURL := "https://www.linkedin.com"
 	s.debugLog("Testing LinkedIn access...")
 
-	resp, err := s.makeRequest(testURL)
+	resp, err := s.makeRequest(ctx, testURL)
 	if err != nil {
 		return fmt.Errorf("failed to access LinkedIn: %v", err)
 	}
-	defer resp.Body.Close()
 
 	s.debugLog("LinkedIn access test: %d %s", resp.StatusCode, resp.Status)
 
@@ -133,179 +264,587 @@ func (s *LinkedInScraper) testLinkedInAccess() error {
 	return nil
 }
 
-// SearchJobs searches for jobs with enhanced debugging and multiple fallback strategies
-func (s *LinkedInScraper) SearchJobs(country, jobTitle string, limit int) ([]Job, error) {
-	// Test LinkedIn access first
-	if err := s.testLinkedInAccess(); err != nil {
-		log.Printf("⚠️  Warning: %v", err)
+// SearchQuery describes a job search: what to look for, where, and which LinkedIn filters to apply
+type SearchQuery struct {
+	Keywords         string   `json:"keywords"`
+	Location         string   `json:"location"`
+	Limit            int      `json:"limit"`
+	ExperienceLevels []string `json:"experience_levels,omitempty"` // internship, entry, associate, mid-senior, director, executive
+	JobTypes         []string `json:"job_types,omitempty"`         // full-time, part-time, contract, temporary, volunteer, internship, other
+	WorkplaceTypes   []string `json:"workplace_types,omitempty"`   // on-site, hybrid, remote
+	DatePosted       string   `json:"date_posted,omitempty"`       // any, 24h, week, month
+	SortBy           string   `json:"sort_by,omitempty"`           // relevance, recent
+	CompanyIDs       []string `json:"company_ids,omitempty"`       // numeric LinkedIn company IDs
+}
+
+// LinkedIn's codes for each search filter value
+var (
+	experienceLevelCodes = map[string]string{
+		"internship": "1", "entry": "2", "associate": "3", "mid-senior": "4", "director": "5", "executive": "6",
+	}
+	jobTypeCodes = map[string]string{
+		"full-time": "F", "part-time": "P", "contract": "C", "temporary": "T", "volunteer": "V", "internship": "I", "other": "O",
+	}
+	workplaceTypeCodes = map[string]string{
+		"on-site": "1", "remote": "2", "hybrid": "3",
+	}
+	datePostedCodes = map[string]string{
+		"any": "", "24h": "r86400", "week": "r604800", "month": "r2592000",
+	}
+	sortByCodes = map[string]string{
+		"relevance": "R", "recent": "DD",
+	}
+)
+
+// Validate checks that every filter value is one LinkedIn understands
+func (q SearchQuery) Validate() error {
+	if q.Keywords == "" && q.Location == "" {
+		return fmt.Errorf("search needs keywords or a location")
+	}
+
+	checks := []struct {
+		name   string
+		values []string
+		codes  map[string]string
+	}{
+		{"experience level", q.ExperienceLevels, experienceLevelCodes},
+		{"job type", q.JobTypes, jobTypeCodes},
+		{"workplace type", q.WorkplaceTypes, workplaceTypeCodes},
+		{"date posted", nonEmpty(q.DatePosted), datePostedCodes},
+		{"sort order", nonEmpty(q.SortBy), sortByCodes},
+	}
+
+	for _, check := range checks {
+		for _, value := range check.values {
+			if _, ok := check.codes[value]; !ok {
+				return fmt.Errorf("unknown %s %q (valid: %s)", check.name, value, strings.Join(sortedKeys(check.codes), ", "))
+			}
+		}
+	}
+
+	for _, id := range q.CompanyIDs {
+		if _, err := strconv.Atoi(id); err != nil {
+			return fmt.Errorf("company ID %q is not numeric", id)
+		}
+	}
+
+	return nil
+}
+
+// params maps the query onto LinkedIn's job search parameters (without paging)
+func (q SearchQuery) params(withSort bool) url.Values {
+	params := url.Values{
+		"keywords": {q.Keywords},
+		"location": {q.Location},
+	}
+
+	addCodes := func(key string, values []string, codes map[string]string) {
+		var mapped []string
+		for _, value := range values {
+			if code := codes[value]; code != "" {
+				mapped = append(mapped, code)
+			}
+		}
+		if len(mapped) > 0 {
+			params.Set(key, strings.Join(mapped, ","))
+		}
+	}
+
+	addCodes("f_E", q.ExperienceLevels, experienceLevelCodes)
+	addCodes("f_JT", q.JobTypes, jobTypeCodes)
+	addCodes("f_WT", q.WorkplaceTypes, workplaceTypeCodes)
+	addCodes("f_TPR", nonEmpty(q.DatePosted), datePostedCodes)
+	if len(q.CompanyIDs) > 0 {
+		params.Set("f_C", strings.Join(q.CompanyIDs, ","))
+	}
+
+	if withSort {
+		sortBy := sortByCodes[q.SortBy]
+		if sortBy == "" {
+			sortBy = "R"
+		}
+		params.Set("sortBy", sortBy)
+	}
+
+	return params
+}
+
+// nonEmpty wraps a single optional value as a slice
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// sortedKeys returns the keys of a code table in alphabetical order, for error messages
+func sortedKeys(codes map[string]string) []string {
+	keys := make([]string, 0, len(codes))
+	for key := range codes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// splitList splits a comma-separated flag value into trimmed, lower-case items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SavedSearch is a named search in the saved searches file
+type SavedSearch struct {
+	Keywords  []string    `json:"keywords"`
+	Locations []string    `json:"locations"`
+	Limit     int         `json:"limit,omitempty"`
+	Filters   SearchQuery `json:"filters"` // experience_levels, job_types, workplace_types, date_posted, sort_by, company_ids
+}
+
+// loadSavedSearch reads the named search from a saved searches file
+func loadSavedSearch(filename, name string) (SavedSearch, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return SavedSearch{}, fmt.Errorf("failed to read saved searches: %v", err)
+	}
+
+	var file struct {
+		Searches map[string]SavedSearch `json:"searches"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return SavedSearch{}, fmt.Errorf("invalid saved searches file %s: %v", filename, err)
+	}
+
+	search, ok := file.Searches[name]
+	if !ok {
+		var names []string
+		for known := range file.Searches {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		return SavedSearch{}, fmt.Errorf("no saved search %q in %s (available: %s)", name, filename, strings.Join(names, ", "))
+	}
+	if len(search.Keywords) == 0 || len(search.Locations) == 0 {
+		return SavedSearch{}, fmt.Errorf("saved search %q needs at least one keyword and one location", name)
+	}
+	return search, nil
+}
+
+// Expand returns one query per keywords/location combination, each keeping q's filters.
+// The first keywords and location are q's own.
+func (q SearchQuery) Expand(keywords, locations []string) []SearchQuery {
+	allKeywords := append([]string{q.Keywords}, keywords...)
+	allLocations := append([]string{q.Location}, locations...)
+
+	var queries []SearchQuery
+	seen := make(map[QueryRef]bool)
+	for _, k := range allKeywords {
+		for _, l := range allLocations {
+			ref := QueryRef{Keywords: strings.TrimSpace(k), Location: strings.TrimSpace(l)}
+			if ref.Keywords == "" || ref.Location == "" || seen[ref] {
+				continue
+			}
+			seen[ref] = true
+
+			expanded := q
+			expanded.Keywords, expanded.Location = ref.Keywords, ref.Location
+			queries = append(queries, expanded)
+		}
+	}
+	return queries
+}
+
+// Ref identifies the query by its keywords and location
+func (q SearchQuery) Ref() QueryRef {
+	return QueryRef{Keywords: q.Keywords, Location: q.Location}
+}
+
+// SearchStats counts what SearchJobs did during a run
+type SearchStats struct {
+	Searches          int `json:"searches"`
+	PagesFetched      int `json:"pages_fetched"`
+	DuplicatesRemoved int `json:"duplicates_removed"`
+	QueryOverlaps     int `json:"query_overlaps"` // jobs found again by a later search of the run
+	FailedSearches    int `json:"failed_searches"`
+	CutOffSearches    int `json:"cut_off_searches,omitempty"`       // searches stopped by the job limit or a bad page before LinkedIn ran out of results
+	TotalResults      int `json:"total_results_reported,omitempty"` // as shown on the results pages, summed over the searches; 0 if unknown
+
+	PageKinds     map[pageclass.Kind]int `json:"page_kinds,omitempty"`      // search pages without job cards, by what they were
+	LastEmptyPage *pageclass.Result      `json:"last_empty_page,omitempty"` // the last of them, for the diagnosis
+}
+
+// searchPager is the pagination state machine for SearchJobs. Pages are tracked by
+// the job IDs they add: a page that adds nothing new ends the search, as does
+// reaching the total results count when the page exposes it.
+type searchPager struct {
+	page  int
+	start int
+	total int
+	seen  map[string]bool
+	stats *SearchStats
+}
+
+// newSearchPager starts pagination at the first page
+func newSearchPager(stats *SearchStats) *searchPager {
+	return &searchPager{page: 1, seen: make(map[string]bool), stats: stats}
+}
+
+// accept returns the jobs whose IDs were not seen on earlier pages or earlier in this page
+func (p *searchPager) accept(jobs []Job) []Job {
+	var fresh []Job
+	for _, job := range jobs {
+		key := jobKey(job)
+		if p.seen[key] {
+			p.stats.DuplicatesRemoved++
+			continue
+		}
+		p.seen[key] = true
+		fresh = append(fresh, job)
+	}
+	return fresh
+}
+
+// advance moves past a page that listed n results
+func (p *searchPager) advance(n int) {
+	p.page++
+	p.start += n
+}
+
+// exhausted reports whether the reported total results have all been paged through
+func (p *searchPager) exhausted() bool {
+	return p.total > 0 && p.start >= p.total
+}
+
+// jobKey identifies a job for deduplication: the LinkedIn job ID when known,
+// otherwise the canonical job URL, otherwise title, company and location
+func jobKey(job Job) string {
+	if job.ID != "" {
+		return job.ID
+	}
+	if job.JobURL != "" {
+		return job.JobURL
+	}
+	return strings.ToLower(job.Title + "|" + job.Company + "|" + job.Location)
+}
+
+// totalResultsPattern finds the first number (with thousands separators) in a results header
+var totalResultsPattern = regexp.MustCompile(`\d[\d,.]*`)
+
+// extractTotalResults reads the total results count from a search page, or 0 if it is not shown
+func (s *LinkedInScraper) extractTotalResults(doc *goquery.Document) int {
+	countSelectors := s.selectors.Search.TotalResults.All()
+
+	for _, selector := range countSelectors {
+		text := strings.TrimSpace(doc.Find(selector).First().Text())
+		match := totalResultsPattern.FindString(text)
+		if match == "" {
+			continue
+		}
+		digits := strings.NewReplacer(",", "", ".", "").Replace(match)
+		if total, err := strconv.Atoi(digits); err == nil && total > 0 {
+			s.debugLog("Page reports %d total results (selector %s)", total, selector)
+			return total
+		}
+	}
+
+	return 0
+}
+
+// SearchStats returns the pagination and deduplication counters for this run
+func (s *LinkedInScraper) SearchStats() SearchStats {
+	return s.searchStats
+}
+
+// SearchJobs searches for jobs with enhanced debugging and multiple fallback strategies.
+// Every fallback keeps the query's filters, so a fallback never widens the search.
+// Jobs are deduplicated by job ID across pages and fallback approaches.
+// Every page is classified (see pageclass): a login wall, auth redirect, challenge or block
+// ends the search with a *pageclass.Error, as does a first page with neither jobs nor a
+// "no results" message. A genuine empty result returns no jobs and no error.
+// If ctx is cancelled the jobs found so far are returned together with the context error.
+func (s *LinkedInScraper) SearchJobs(ctx context.Context, query SearchQuery) ([]Job, error) {
+	// Test LinkedIn access first (once per run)
+	if !s.accessOK {
+		if err := s.testLinkedInAccess(ctx); err != nil {
+			if cause := stopCause(ctx, err); cause != nil {
+				return nil, cause
+			}
+			log.Printf("⚠️  Warning: %v", err)
+		}
+		s.accessOK = true
 	}
 
 	baseURL := "https://www.linkedin.com/jobs/search"
+	limit := query.Limit
+	s.searchStats.Searches++
 
 	var allJobs []Job
-	start := 0
+	var lastFailure error
+	pager := newSearchPager(&s.searchStats)
+	complete := false // LinkedIn ran out of results, so every matching posting was listed
 
 	for len(allJobs) < limit {
 		// Try multiple parameter combinations for better success rate
+		primary := query.params(true)
+		primary.Set("start", fmt.Sprintf("%d", pager.start))
+
+		simple := query.params(false)
+		simple.Set("start", fmt.Sprintf("%d", pager.start))
+
 		paramSets := []url.Values{
-			// Without time restriction (more results) - PRIMARY APPROACH
-			{
-				"keywords": {jobTitle},
-				"location": {country},
-				"start":    {fmt.Sprintf("%d", start)},
-				"sortBy":   {"R"},
-			},
-			// Original parameters with time filter
-			{
-				"keywords": {jobTitle},
-				"location": {country},
-				"f_TPR":    {"r604800"}, // Last week
-				"start":    {fmt.Sprintf("%d", start)},
-				"sortBy":   {"R"},
-			},
-			// Simple parameters
-			{
-				"keywords": {jobTitle},
-				"location": {country},
-				"start":    {fmt.Sprintf("%d", start)},
-			},
-			// Most basic approach
-			{
-				"keywords": {jobTitle},
-				"location": {country},
-			},
+			// Requested filters and sort order - PRIMARY APPROACH
+			primary,
+			// Simple parameters (LinkedIn's default sort)
+			simple,
+		}
+		if pager.page == 1 {
+			// Most basic approach - it cannot page, so it is only tried for the first page
+			paramSets = append(paramSets, query.params(false))
 		}
 
 		var pageJobs []Job
+		var empty *pageclass.Result // the last page without jobs
 
 		// Try each parameter set until one works
 		for i, params := range paramSets {
 			searchURL := fmt.Sprintf("%s?%s", baseURL, params.Encode())
 
 			if i == 0 {
-				log.Printf("🔍 Searching jobs (page %d): %s", start/25+1, searchURL)
+				log.Printf("🔍 Searching jobs (page %d): %s", pager.page, searchURL)
 			} else {
 				s.debugLog("Trying fallback approach %d: %s", i+1, searchURL)
 			}
 
-			resp, err := s.makeRequest(searchURL)
+			resp, err := s.makeRequest(ctx, searchURL)
 			if err != nil {
+				if cause := stopCause(ctx, err); cause != nil {
+					return allJobs, cause
+				}
+				var retryErr *fetcher.RetryError
+				var disallowed *fetcher.DisallowedError
+				if errors.As(err, &retryErr) || errors.As(err, &disallowed) {
+					log.Printf("⚠️  Search approach %d failed: %v", i+1, err)
+					lastFailure = err
+				}
 				s.debugLog("Request failed for approach %d: %v", i+1, err)
 				continue
 			}
-			defer resp.Body.Close()
 
-			if resp.StatusCode == 999 {
-				return nil, fmt.Errorf("LinkedIn is blocking requests (status 999). Try:\n1. Using a VPN\n2. Waiting and trying later\n3. Using different search terms")
+			doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body))
+			if err != nil {
+				s.debugLog("Parse failed for approach %d: %v", i+1, err)
 			}
 
-			if resp.StatusCode != 200 {
-				s.debugLog("Non-200 response for approach %d: %d", i+1, resp.StatusCode)
-				continue
+			var jobs []Job
+			var pageHealth *selectors.PageHealth
+			if resp.StatusCode == 200 && doc != nil {
+				s.searchStats.PagesFetched++
+				pageHealth = s.health.Page(searchURL)
+
+				// Debug: save page content if in debug mode
+				if s.debug {
+					html, _ := doc.Html()
+					filename := fmt.Sprintf("debug_approach_%d_page_%d.html", i+1, pager.page)
+					os.WriteFile(filename, []byte(html), 0644)
+					s.debugLog("Saved debug page to: %s", filename)
+				}
+
+				jobs = s.extractJobsFromDocument(doc, fetchedAt(resp), pageHealth)
+				s.debugLog("Approach %d extracted %d jobs from page %d", i+1, len(jobs), pager.page)
 			}
 
-			doc, err := goquery.NewDocumentFromReader(resp.Body)
-			if err != nil {
-				s.debugLog("Parse failed for approach %d: %v", i+1, err)
+			result := pageclass.Classify(pageclass.Page{URL: searchURL, FinalURL: resp.FinalURL, Status: resp.StatusCode, Doc: doc, Items: len(jobs)})
+			if result.Kind != pageclass.Content {
+				if result.Kind == pageclass.UnknownLayout {
+					pageHealth.Record("search.job_cards", -1) // only pages nothing explains count as selector misses
+				}
+				s.notePageKind(result)
+				s.debugLog("Approach %d got %s: %s", i+1, result.Kind.Describe(), strings.Join(result.Evidence, "; "))
+				if result.Kind.Blocking() {
+					return allJobs, &pageclass.Error{Result: result}
+				}
+				empty = &result
+				if result.Kind == pageclass.NoResults {
+					break // the fallbacks keep the same filters, so they cannot find more
+				}
 				continue
 			}
 
-			// Debug: save page content if in debug mode
-			if s.debug {
-				html, _ := doc.Html()
-				filename := fmt.Sprintf("debug_approach_%d_page_%d.html", i+1, start/25+1)
-				os.WriteFile(filename, []byte(html), 0644)
-				s.debugLog("Saved debug page to: %s", filename)
+			pageJobs = jobs
+			if pager.total == 0 {
+				pager.total = s.extractTotalResults(doc)
+				s.searchStats.TotalResults += pager.total
 			}
-
-			jobs := s.extractJobsFromDocument(doc)
-			s.debugLog("Approach %d extracted %d jobs from page %d", i+1, len(jobs), start/25+1)
-
-			if len(jobs) > 0 {
-				pageJobs = jobs
-				if i > 0 {
-					log.Printf("✅ Success with fallback approach %d - found %d jobs", i+1, len(jobs))
-				}
-				break // Found jobs, use this approach
+			if i > 0 {
+				log.Printf("✅ Success with fallback approach %d - found %d jobs", i+1, len(jobs))
 			}
+			break // Found jobs, use this approach
 		}
 
 		if len(pageJobs) == 0 {
-			s.debugLog("No jobs found with any approach on page %d", start/25+1)
-
-			// Enhanced blocking detection
-			if start == 0 { // Only check on first page
-				log.Printf("🔍 Analyzing why no jobs were found...")
-				// The debug files will contain the actual page content for analysis
+			s.debugLog("No jobs found with any approach on page %d", pager.page)
+
+			switch {
+			case empty == nil:
+				// every request failed; lastFailure says why
+			case empty.Kind == pageclass.NoResults:
+				log.Printf("ℹ️  LinkedIn reports no matching jobs on page %d", pager.page)
+				complete = true
+			case pager.page == 1:
+				// Neither jobs nor a "no results" message: an empty result would hide the real problem
+				return allJobs, &pageclass.Error{Result: *empty}
 			}
 
-			log.Printf("ℹ️  No more jobs found on page %d", start/25+1)
+			log.Printf("ℹ️  No more jobs found on page %d", pager.page)
+			break
+		}
+
+		newJobs := pager.accept(pageJobs)
+		if len(query.WorkplaceTypes) == 1 {
+			// LinkedIn already filtered on the one requested workplace type
+			for i := range newJobs {
+				if newJobs[i].WorkplaceType == geo.Unknown {
+					newJobs[i].WorkplaceType = geo.ParseWorkplace(query.WorkplaceTypes[0])
+				}
+			}
+		}
+		if len(newJobs) == 0 {
+			log.Printf("ℹ️  Page %d only repeated jobs already found - stopping pagination", pager.page)
+			complete = true
 			break
 		}
+		if removed := len(pageJobs) - len(newJobs); removed > 0 {
+			s.debugLog("Removed %d duplicate jobs on page %d", removed, pager.page)
+		}
 
-		allJobs = append(allJobs, pageJobs...)
+		allJobs = append(allJobs, newJobs...)
 
 		if len(allJobs) >= limit {
+			// Complete only when no job was cut off and LinkedIn reported no more
+			complete = len(allJobs) == limit && pager.total > 0 && pager.start+len(pageJobs) >= pager.total
 			allJobs = allJobs[:limit]
 			break
 		}
 
-		start += 25
+		pager.advance(len(pageJobs))
+		if pager.exhausted() {
+			log.Printf("ℹ️  Reached the %d results LinkedIn reported", pager.total)
+			complete = true
+			break
+		}
+	}
+	if !complete {
+		s.searchStats.CutOffSearches++
+	}
+
+	if len(allJobs) == 0 && lastFailure != nil {
+		return nil, fmt.Errorf("search requests kept failing: %v", lastFailure)
+	}
+
+	if s.searchStats.DuplicatesRemoved > 0 {
+		log.Printf("✅ Found %d jobs total (%d duplicates removed)", len(allJobs), s.searchStats.DuplicatesRemoved)
+	} else {
+		log.Printf("✅ Found %d jobs total", len(allJobs))
+	}
+	return allJobs, nil
+}
+
+// notePageKind counts a search page without job cards
+func (s *LinkedInScraper) notePageKind(result pageclass.Result) {
+	if s.searchStats.PageKinds == nil {
+		s.searchStats.PageKinds = make(map[pageclass.Kind]int)
+	}
+	s.searchStats.PageKinds[result.Kind]++
+	s.searchStats.LastEmptyPage = &result
+}
+
+// SearchAll runs every query in turn and merges the results, sharing one job limit and
+// the fetcher's request budget. Each query gets a fair share of the jobs still missing,
+// so a query that finds little leaves more room for the next. Jobs found by several
+// queries are kept once, tagged with every query that found them.
+func (s *LinkedInScraper) SearchAll(ctx context.Context, queries []SearchQuery, limit int) ([]Job, error) {
+	if len(queries) > 1 {
+		log.Printf("🔀 Running %d searches sharing a limit of %d jobs", len(queries), limit)
+	}
+
+	var allJobs []Job
+	index := make(map[string]int) // job key -> position in allJobs
+	var lastErr error
+	failed := 0
+
+	for i, query := range queries {
+		remaining := limit - len(allJobs)
+		if remaining <= 0 {
+			log.Printf("ℹ️  Job limit reached - skipping %d remaining searches", len(queries)-i)
+			s.searchStats.CutOffSearches += len(queries) - i
+			break
+		}
+		query.Limit = (remaining + len(queries) - i - 1) / (len(queries) - i) // fair share, rounded up
+
+		if len(queries) > 1 {
+			log.Printf("🔎 Search %d/%d: %s (up to %d jobs)", i+1, len(queries), query.Ref(), query.Limit)
+		}
+
+		jobs, err := s.SearchJobs(ctx, query)
+		for _, job := range jobs {
+			key := jobKey(job)
+			if at, ok := index[key]; ok {
+				allJobs[at].Queries = append(allJobs[at].Queries, query.Ref())
+				s.searchStats.QueryOverlaps++
+				continue
+			}
+			if len(queries) > 1 {
+				job.Queries = []QueryRef{query.Ref()}
+			}
+			index[key] = len(allJobs)
+			allJobs = append(allJobs, job)
+		}
 
-		s.debugLog("Waiting %v before next request...", s.delay)
-		time.Sleep(s.delay)
+		if err != nil {
+			if cause := stopCause(ctx, err); cause != nil {
+				return allJobs, cause
+			}
+			var pageErr *pageclass.Error
+			if len(queries) == 1 || errors.As(err, &pageErr) && pageErr.Kind.Blocking() {
+				return allJobs, err // a wall or block would stop the next searches too
+			}
+			log.Printf("⚠️  Search %s failed: %v", query.Ref(), err)
+			lastErr = err
+			failed++
+			s.searchStats.FailedSearches++
+		}
 	}
 
-	log.Printf("✅ Found %d jobs total", len(allJobs))
+	if failed == len(queries) {
+		return allJobs, lastErr
+	}
+	if len(queries) > 1 {
+		log.Printf("✅ %d unique jobs from %d searches (%d found by more than one search)", len(allJobs), len(queries), s.searchStats.QueryOverlaps)
+	}
 	return allJobs, nil
 }
 
-// extractJobsFromDocument extracts job listings with current LinkedIn selectors (2025)
-func (s *LinkedInScraper) extractJobsFromDocument(doc *goquery.Document) []Job {
+// extractJobsFromDocument extracts job listings from the page's structured data, or with the
+// configured selectors (see selectors/default.json) when it embeds none. The winning selectors are recorded in page;
+// misses are recorded by SearchJobs, which knows whether the page was meant to have job cards.
+func (s *LinkedInScraper) extractJobsFromDocument(doc *goquery.Document, fetched time.Time, page *selectors.PageHealth) []Job {
+	if jobs := s.jobsFromStructuredData(doc, fetched); len(jobs) > 0 {
+		page.RecordStructured(len(jobs))
+		return jobs
+	}
+
 	var jobs []Job
 
-	// UPDATED: Current LinkedIn selectors as of 2025
-	// Based on LinkedIn's current page structure
-	selectors := []string{
-		// Primary current selectors (2025)
-		".jobs-search-results-list .jobs-search-results__list-item",
-		".jobs-search-results__list-item",
-		".scaffold-layout__list-item",
-		".artdeco-list__item",
-		"[data-entity-urn*='jobPosting']",
-		"[data-occludable-job-id]",
-
-		// Secondary current selectors
-		".job-card-container",
-		".job-card-list__entity-lockup",
-		".entity-result",
-		".search-result",
-		"[data-job-id]",
-
-		// Backup selectors for different page layouts
-		".base-search-card",
-		".job-search-card",
-		".jobs-search__results-list li",
-		".job-result-card",
-		".base-card",
-		"li[data-occludable-job-id]",
-		".scaffold-layout__list-container li",
-
-		// Generic fallbacks
-		"article",
-		".card",
-		"[data-urn]",
-		"li[data-urn]",
-		"div[class*='job']",
-		"li[class*='job']",
-		"[class*='search-result']",
-		"li[class*='entity']",
-		".entity-lockup",
-	}
-
-	s.debugLog("Trying %d different selectors for job extraction", len(selectors))
-
-	for i, selector := range selectors {
+	cardSelectors := s.selectors.Search.JobCards.All()
+
+	s.debugLog("Trying %d different selectors for job extraction", len(cardSelectors))
+
+	for i, selector := range cardSelectors {
 		s.debugLog("Trying selector %d: %s", i+1, selector)
 
 		jobElements := doc.Find(selector)
@@ -313,16 +852,27 @@ func (s *LinkedInScraper) extractJobsFromDocument(doc *goquery.Document) []Job {
 
 		if jobElements.Length() > 0 {
 			var selectorJobs []Job
+			var selectorHits []map[string]int
 			jobElements.Each(func(j int, sel *goquery.Selection) {
-				job := s.extractJobFromElement(sel)
+				job, hits := s.extractJobFromElement(sel, fetched)
 				if job.Title != "" && job.Company != "" {
 					selectorJobs = append(selectorJobs, job)
+					selectorHits = append(selectorHits, hits)
 					s.debugLog("Extracted job %d: %s at %s", j+1, job.Title, job.Company)
 				}
 			})
 
 			if len(selectorJobs) > 0 {
 				s.debugLog("Successfully extracted %d jobs with selector: %s", len(selectorJobs), selector)
+				if s.selectors.Search.JobCards.Tier(i) == selectors.TierFallback {
+					log.Printf("🚨 Job cards only matched the generic fallback selector %q - results may include junk", selector)
+				}
+				page.Record("search.job_cards", i)
+				for _, hits := range selectorHits {
+					for cascade, index := range hits {
+						page.Record(cascade, index)
+					}
+				}
 				jobs = append(jobs, selectorJobs...)
 				break // Found jobs with this selector
 			}
@@ -331,235 +881,280 @@ func (s *LinkedInScraper) extractJobsFromDocument(doc *goquery.Document) []Job {
 
 	if len(jobs) == 0 {
 		s.debugLog("⚠️  No jobs extracted with any selector")
-		if s.debug {
-			// Enhanced page structure analysis
-			pageStructure := s.analyzePageStructure(doc)
-			log.Printf("Page structure analysis:\n%s", pageStructure)
-		}
 	}
 
 	return jobs
 }
 
-// extractJobFromElement extracts job details with current LinkedIn selectors (2025)
-func (s *LinkedInScraper) extractJobFromElement(sel *goquery.Selection) Job {
+// jobsFromStructuredData reads the jobs a page embeds as JSON-LD or hydration JSON.
+// Postings without a title and company are left to the selectors.
+func (s *LinkedInScraper) jobsFromStructuredData(doc *goquery.Document, fetched time.Time) []Job {
+	var jobs []Job
+	postings := structured.Find(doc)
+	for i := range postings {
+		job := Job{}
+		s.applyPosting(&job, &postings[i], fetched)
+		if job.Title == "" || job.Company == "" {
+			continue
+		}
+		jobs = append(jobs, job)
+		s.debugLog("Extracted job from %s: %s at %s", postings[i].Source, job.Title, job.Company)
+	}
+	return jobs
+}
+
+// applyPosting fills a job from structured data. Its salary and posted date win over the
+// card's, which LinkedIn rounds; the other fields only fill gaps.
+func (s *LinkedInScraper) applyPosting(job *Job, p *structured.Posting, fetched time.Time) {
+	if job.Title == "" {
+		job.Title = p.Title
+	}
+	if job.Company == "" {
+		job.Company = p.Company
+	}
+	if job.CompanyURL == "" && strings.Contains(p.CompanyURL, "linkedin.com/company/") {
+		job.CompanyURL = linkedinurl.CanonicalCompanyURL(p.CompanyURL)
+	}
+	if job.Location == "" && p.Location != "" {
+		place, workplace, loc := s.places.Parse(p.Location)
+		job.Location = loc
+		if !place.IsZero() {
+			job.Place = &place
+		}
+		if workplace != geo.Unknown {
+			job.WorkplaceType = workplace
+		}
+	}
+	if p.Remote && job.WorkplaceType == geo.Unknown {
+		job.WorkplaceType = geo.Remote
+	}
+	notePosted(job, p.DatePosted, fetched)
+	if p.Salary != nil {
+		job.Salary = p.Salary
+	}
+
+	if job.ID == "" {
+		job.ID = postingJobID(p)
+	}
+	if job.JobURL == "" {
+		job.JobURL = linkedinurl.CanonicalJobURL(p.URL)
+		if job.ID != "" {
+			job.JobURL = linkedinurl.JobURL(job.ID)
+		}
+	}
+}
+
+// postingJobID returns the LinkedIn job ID of an embedded posting, or "" if it names none
+func postingJobID(p *structured.Posting) string {
+	if id := linkedinurl.JobIDFromURL(p.URL); id != "" {
+		return id
+	}
+	return linkedinurl.JobIDFromAttr(p.Identifier)
+}
+
+// postingFor picks the posting of the job with jobID from the structured data of its page
+func postingFor(postings []structured.Posting, jobID string) *structured.Posting {
+	for i := range postings {
+		if id := postingJobID(&postings[i]); id != "" && id == jobID {
+			return &postings[i]
+		}
+	}
+	if len(postings) == 1 && (jobID == "" || postingJobID(&postings[0]) == "") {
+		return &postings[0]
+	}
+	return nil
+}
+
+// extractJobFromElement extracts job details with the configured selectors.
+// Relative posted times on the card count back from fetched. It also returns the index
+// of the title, company and location selector that matched (-1 for none).
+func (s *LinkedInScraper) extractJobFromElement(sel *goquery.Selection, fetched time.Time) (Job, map[string]int) {
 	job := Job{}
+	hits := map[string]int{"search.title": -1, "search.company": -1, "search.location": -1}
+
+	titleSelectors := s.selectors.Search.Title.All()
 
-	// UPDATED: Current LinkedIn title selectors (2025)
-	titleSelectors := []string{
-		// Primary current selectors
-		".job-card-list__title a",
-		".job-card-container__link",
-		".artdeco-entity-lockup__title a",
-		".entity-result__title-text a",
-		".base-search-card__title a",
-		"[data-control-name='job_search_job_result_title']",
-
-		// Secondary selectors
-		".job-result-card__title a",
-		"h3 a",
-		"h2 a",
-		".job-search-card__title a",
-		"a[data-control-name='job_search_job_result_title']",
-		".job-card__title a",
-		".job-title a",
-		".jobs-unified-top-card__job-title a",
-		"[aria-label*='job']",
-
-		// Generic fallbacks
-		"a[href*='/jobs/view/']",
-		"a[href*='/jobs/collections/']",
-	}
-
-	for _, titleSel := range titleSelectors {
+	for i, titleSel := range titleSelectors {
 		titleLink := sel.Find(titleSel).First()
 		if titleLink.Length() > 0 {
 			title := strings.TrimSpace(titleLink.Text())
 			if title != "" && len(title) > 3 { // Basic validation
 				job.Title = title
 				if href, exists := titleLink.Attr("href"); exists {
-					job.JobURL = s.normalizeURL(href)
+					job.JobURL = linkedinurl.CanonicalJobURL(s.normalizeURL(href))
 				}
 				s.debugLog("Found title with selector %s: %s", titleSel, job.Title)
+				hits["search.title"] = i
 				break
 			}
 		}
 	}
 
-	// UPDATED: Current LinkedIn company selectors (2025)
-	companySelectors := []string{
-		// Primary current selectors
-		".job-card-container__primary-description",
-		".artdeco-entity-lockup__subtitle a",
-		".entity-result__primary-subtitle a",
-		".base-search-card__subtitle a",
-		"[data-control-name='job_search_company_name']",
-
-		// Secondary selectors
-		".hidden-nested-link",
-		".job-result-card__subtitle a",
-		"h4 a",
-		".job-search-card__subtitle a",
-		"a[data-control-name='job_search_company_name']",
-		".job-card__subtitle a",
-		".company-name a",
-		".jobs-unified-top-card__company-name a",
-		".job-result-card__subtitle-link",
-
-		// Generic fallbacks
-		"a[href*='/company/']",
-		"a[href*='/school/']",
-	}
+	companySelectors := s.selectors.Search.Company.All()
 
-	for _, companySel := range companySelectors {
+	for i, companySel := range companySelectors {
 		companyLink := sel.Find(companySel).First()
 		if companyLink.Length() > 0 {
 			company := strings.TrimSpace(companyLink.Text())
 			if company != "" && len(company) > 1 { // Basic validation
 				job.Company = company
 				if href, exists := companyLink.Attr("href"); exists {
-					job.CompanyURL = s.normalizeURL(href)
+					job.CompanyURL = linkedinurl.CanonicalCompanyURL(s.normalizeURL(href))
 				}
 				s.debugLog("Found company with selector %s: %s", companySel, job.Company)
+				hits["search.company"] = i
 				break
 			}
 		}
 	}
 
-	// UPDATED: Current LinkedIn location selectors (2025)
-	locationSelectors := []string{
-		// Primary current selectors
-		".job-card-container__metadata-wrapper",
-		".artdeco-entity-lockup__caption",
-		".entity-result__secondary-subtitle",
-		".base-search-card__metadata",
-		".job-result-card__location",
-
-		// Secondary selectors
-		".job-search-card__location",
-		"[data-test='job-location']",
-		".job-search-card__location span",
-		".job-card__location",
-		".job-location",
-		".jobs-unified-top-card__bullet",
-		".location",
+	salarySelectors := s.selectors.Search.Salary.All()
 
-		// Generic fallbacks that might contain location
-		".job-card-container__metadata",
-		".metadata",
+	var salaryText string
+	for _, salarySel := range salarySelectors {
+		salaryText = sel.Find(salarySel).First().Text()
+		if pay, ok := salary.Parse(salaryText); ok {
+			job.Salary = &pay
+			s.debugLog("Found salary with selector %s: %s", salarySel, pay)
+			break
+		}
 	}
 
-	for _, locSel := range locationSelectors {
+	locationSelectors := s.selectors.Search.Location.All()
+
+	for i, locSel := range locationSelectors {
 		location := sel.Find(locSel).First()
 		if location.Length() > 0 {
-			loc := strings.TrimSpace(location.Text())
+			// Metadata selectors also hold posted-time, applicant and salary text; only the place counts
+			text := location.Text()
+			if job.Salary != nil {
+				text = strings.Replace(text, salaryText, " ", 1)
+			}
+			place, workplace, loc := s.places.Parse(text)
 			if loc != "" && len(loc) > 2 { // Basic validation
 				job.Location = loc
-				s.debugLog("Found location with selector %s: %s", locSel, job.Location)
+				if !place.IsZero() {
+					job.Place = &place
+				}
+				job.WorkplaceType = workplace
+				s.debugLog("Found location with selector %s: %s (%s)", locSel, job.Location, place)
+				hits["search.location"] = i
 				break
 			}
+			if workplace != geo.Unknown {
+				job.WorkplaceType = workplace
+			}
 		}
 	}
 
-	return job
-}
-
-// analyzePageStructure analyzes the page structure for debugging with enhanced detection
-func (s *LinkedInScraper) analyzePageStructure(doc *goquery.Document) string {
-	var analysis strings.Builder
+	postedSelectors := s.selectors.Search.Posted.All()
 
-	analysis.WriteString("=== ENHANCED PAGE STRUCTURE ANALYSIS ===\n")
-
-	// Check for various job-related classes and IDs
-	checkElements := []string{
-		".job-search-card", ".jobs-search__results-list", ".job-result-card",
-		".base-card", ".scaffold-layout__list-container", "[data-entity-urn]",
-		".jobs-search-results__list-item", ".job-card-container", ".job-card-search",
-		"[data-job-id]", ".artdeco-entity-lockup", ".job-card-square",
-		".jobs-search__job-card", ".jobs-unified-top-card", "article", ".card",
-		"[data-urn]", "li[data-urn]", "li", "div[class*='job']", "[class*='search']",
-		"main", "#main", ".main-content", ".content", ".jobs-search",
-	}
-
-	foundElements := 0
-	for _, selector := range checkElements {
-		count := doc.Find(selector).Length()
-		if count > 0 {
-			analysis.WriteString(fmt.Sprintf("✅ Found %d elements with '%s'\n", count, selector))
-			foundElements += count
+	for _, postedSel := range postedSelectors {
+		postedEl := sel.Find(postedSel).First()
+		if postedEl.Length() == 0 {
+			continue
+		}
+		// The text ("3 hours ago") can be finer than the datetime attribute, which only has the day
+		datetime, _ := postedEl.Attr("datetime")
+		if notePosted(&job, postedEl.Text(), fetched) || notePosted(&job, datetime, fetched) {
+			s.debugLog("Found posted time with selector %s: %s (%s)", postedSel, job.PostedAt.Format(time.RFC3339), job.PostedPrecision)
+			break
 		}
 	}
 
-	if foundElements == 0 {
-		analysis.WriteString("❌ No job-related elements found with standard selectors\n")
+	job.ID = s.extractJobID(sel, job.JobURL)
+	if job.ID != "" {
+		job.JobURL = linkedinurl.JobURL(job.ID)
 	}
 
-	// Check page title and meta info
-	title := doc.Find("title").Text()
-	analysis.WriteString(fmt.Sprintf("📄 Page title: %s\n", strings.TrimSpace(title)))
+	return job, hits
+}
 
-	// Check for specific LinkedIn indicators
-	indicators := []string{"linkedin.com/jobs", "job search", "job results", "jobs found", "no jobs", "search results"}
-	pageText := strings.ToLower(doc.Text())
-	for _, indicator := range indicators {
-		if strings.Contains(pageText, indicator) {
-			analysis.WriteString(fmt.Sprintf("🔍 Found indicator: '%s'\n", indicator))
-		}
+// notePosted parses a posted time and keeps it unless the job already has a finer one
+func notePosted(job *Job, text string, fetched time.Time) bool {
+	at, precision, ok := posted.Parse(text, fetched)
+	if !ok {
+		return false
 	}
+	if job.PostedAt == nil || precision.Finer(job.PostedPrecision) {
+		job.PostedAt, job.PostedPrecision = &at, precision
+	}
+	return true
+}
 
-	// Check for error/blocking messages
-	errorKeywords := []string{"blocked", "captcha", "verification", "error", "access denied", "sign in", "login", "please try again"}
-	for _, keyword := range errorKeywords {
-		if strings.Contains(pageText, keyword) {
-			analysis.WriteString(fmt.Sprintf("⚠️  Found warning keyword: '%s'\n", keyword))
-		}
+// extractJobID finds the LinkedIn job ID of a card: from the job link, then from
+// data-entity-urn / data-job-id attributes on the card, its children or its parents
+func (s *LinkedInScraper) extractJobID(sel *goquery.Selection, jobURL string) string {
+	if id := linkedinurl.JobIDFromURL(jobURL); id != "" {
+		return id
 	}
 
-	// Count total elements for context
-	totalElements := doc.Find("*").Length()
-	analysis.WriteString(fmt.Sprintf("📊 Total page elements: %d\n", totalElements))
+	attrs := []string{"data-entity-urn", "data-job-id", "data-occludable-job-id", "data-urn"}
+	for _, attr := range attrs {
+		candidates := []*goquery.Selection{
+			sel,
+			sel.Find("[" + attr + "]").First(),
+			sel.ParentsFiltered("[" + attr + "]").First(),
+		}
+		for _, candidate := range candidates {
+			if value, exists := candidate.Attr(attr); exists {
+				if id := linkedinurl.JobIDFromAttr(value); id != "" {
+					s.debugLog("Found job ID %s in %s", id, attr)
+					return id
+				}
+			}
+		}
+	}
 
-	return analysis.String()
+	return ""
 }
 
 // normalizeURL normalizes LinkedIn URLs
 func (s *LinkedInScraper) normalizeURL(href string) string {
-	if strings.HasPrefix(href, "http") {
-		return href
-	}
-	if strings.HasPrefix(href, "/") {
-		return "https://www.linkedin.com" + href
-	}
-	return href
+	return linkedinurl.Absolute(href)
 }
 
 // CheckIndonesianEmployees efficiently checks if a company has Indonesian employees
-func (s *LinkedInScraper) CheckIndonesianEmployees(companyURL string) (bool, []Employee, error) {
+func (s *LinkedInScraper) CheckIndonesianEmployees(ctx context.Context, companyURL string) (bool, []Employee, error) {
 	startTime := time.Now()
 
 	if companyURL == "" {
 		return false, nil, fmt.Errorf("empty company URL")
 	}
 
-	time.Sleep(s.delay)
-
 	// Try multiple approaches for finding employees
-	approaches := []func(string) ([]Employee, error){
+	approaches := []func(context.Context, string) ([]Employee, error){
 		s.checkCompanyPeoplePage,
 		s.checkCompanyAboutPage,
 		s.searchEmployeesDirectly,
 	}
 
 	var allEmployees []Employee
+	var lastErr error
+	var failed error // a lookup that failed, which makes finding no one inconclusive; walls win
+	succeeded := false
 
 	for i, approach := range approaches {
 		s.debugLog("Trying approach %d for employee detection", i+1)
-		employees, err := approach(companyURL)
+		employees, err := approach(ctx, companyURL)
 		if err != nil {
+			if cause := stopCause(ctx, err); cause != nil {
+				return false, nil, cause
+			}
 			s.debugLog("Approach %d failed: %v", i+1, err)
+			lastErr = err
+			var disallowed *fetcher.DisallowedError
+			if errors.As(err, &disallowed) {
+				continue // robots.txt rules the lookup out every time; it did not fail
+			}
+			var pageErr, failedPage *pageclass.Error
+			if failed == nil || errors.As(err, &pageErr) && !errors.As(failed, &failedPage) {
+				failed = err
+			}
 			continue
 		}
 
+		succeeded = true
 		allEmployees = append(allEmployees, employees...)
 
 		if len(allEmployees) > 0 {
@@ -567,6 +1162,14 @@ func (s *LinkedInScraper) CheckIndonesianEmployees(companyURL string) (bool, []E
 		}
 	}
 
+	// Report the cause instead of a false "no Indonesian employees" when a lookup failed
+	if len(allEmployees) == 0 && failed != nil {
+		return false, nil, fmt.Errorf("no employees found, but a lookup failed: %w", failed)
+	}
+	if !succeeded {
+		return false, nil, fmt.Errorf("all employee lookups failed, last error: %v", lastErr)
+	}
+
 	// Remove duplicates and sort by confidence
 	allEmployees = s.deduplicateEmployees(allEmployees)
 
@@ -578,84 +1181,119 @@ func (s *LinkedInScraper) CheckIndonesianEmployees(companyURL string) (bool, []E
 	return hasIndonesian, allEmployees, nil
 }
 
-// checkCompanyPeoplePage checks the company's people page
-func (s *LinkedInScraper) checkCompanyPeoplePage(companyURL string) ([]Employee, error) {
-	peopleURL := strings.Replace(companyURL, "/company/", "/company/", 1) + "/people/"
+// checkCompanyCached runs CheckIndonesianEmployees through the company cache, so duplicate
+// companies in one run are checked once and recent results from earlier runs are reused
+func (s *LinkedInScraper) checkCompanyCached(ctx context.Context, companyURL string) (bool, []Employee, *CacheInfo, error) {
+	if companyURL == "" {
+		hasIndonesian, employees, err := s.CheckIndonesianEmployees(ctx, companyURL)
+		return hasIndonesian, employees, nil, err
+	}
 
-	resp, err := s.makeRequest(peopleURL)
+	var employees []Employee
+	entry, status, err := s.companies.Lookup(companyURL, func() (companycache.Entry, error) {
+		hasIndonesian, found, err := s.CheckIndonesianEmployees(ctx, companyURL)
+		if err != nil {
+			return companycache.Entry{}, err
+		}
+		data, err := json.Marshal(found)
+		if err != nil {
+			return companycache.Entry{}, err
+		}
+		return companycache.Entry{HasIndonesian: hasIndonesian, Employees: data}, nil
+	})
 	if err != nil {
-		return nil, err
+		return false, nil, nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("people page returned status %d", resp.StatusCode)
+	if err := json.Unmarshal(entry.Employees, &employees); err != nil {
+		return false, nil, nil, fmt.Errorf("corrupt cache entry for %s: %v", entry.CompanyURL, err)
 	}
 
-	return s.extractEmployeesFromHTML(resp.Body)
+	info := &CacheInfo{
+		Status:    string(status),
+		CheckedAt: entry.CheckedAt.Format("2006-01-02 15:04:05"),
+		Age:       entry.Age().Round(time.Second).String(),
+	}
+	if status == companycache.Cached {
+		log.Printf("♻️  Reusing cached result for %s (checked %s ago)", companycache.Key(companyURL), info.Age)
+	}
+
+	return entry.HasIndonesian, employees, info, nil
+}
+
+// checkCompanyPeoplePage checks the company's people page
+func (s *LinkedInScraper) checkCompanyPeoplePage(ctx context.Context, companyURL string) ([]Employee, error) {
+	peopleURL := strings.Replace(companyURL, "/company/", "/company/", 1) + "/people/"
+	return s.fetchEmployees(ctx, peopleURL, "people page")
 }
 
 // checkCompanyAboutPage checks the company's about page
-func (s *LinkedInScraper) checkCompanyAboutPage(companyURL string) ([]Employee, error) {
+func (s *LinkedInScraper) checkCompanyAboutPage(ctx context.Context, companyURL string) ([]Employee, error) {
 	aboutURL := strings.Replace(companyURL, "/company/", "/company/", 1) + "/about/"
-
-	resp, err := s.makeRequest(aboutURL)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("about page returned status %d", resp.StatusCode)
-	}
-
-	return s.extractEmployeesFromHTML(resp.Body)
+	return s.fetchEmployees(ctx, aboutURL, "about page")
 }
 
 // searchEmployeesDirectly searches for employees using LinkedIn search
-func (s *LinkedInScraper) searchEmployeesDirectly(companyURL string) ([]Employee, error) {
+func (s *LinkedInScraper) searchEmployeesDirectly(ctx context.Context, companyURL string) ([]Employee, error) {
 	companyName := s.extractCompanyName(companyURL)
 	if companyName == "" {
 		return nil, fmt.Errorf("could not extract company name")
 	}
 
 	searchURL := fmt.Sprintf("https://www.linkedin.com/search/results/people/?currentCompany=%%5B%%22%s%%22%%5D", url.QueryEscape(companyName))
+	return s.fetchEmployees(ctx, searchURL, "people search")
+}
 
-	resp, err := s.makeRequest(searchURL)
+// fetchEmployees loads a company or people search page and extracts Indonesian employees.
+// A login wall, auth redirect, challenge or block comes back as a *pageclass.Error.
+func (s *LinkedInScraper) fetchEmployees(ctx context.Context, pageURL, page string) ([]Employee, error) {
+	resp, err := s.makeRequest(ctx, pageURL)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	return s.extractEmployeesFromHTML(resp.Body)
-}
+	doc, parseErr := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body))
+	var employees []Employee
+	names := 0
+	if resp.StatusCode == 200 && parseErr == nil {
+		employees, names = s.extractEmployeesFromDocument(resp.URL, doc)
+	}
 
-// extractEmployeesFromHTML extracts Indonesian employees from HTML content using efficient name lookup
-func (s *LinkedInScraper) extractEmployeesFromHTML(body io.Reader) ([]Employee, error) {
-	doc, err := goquery.NewDocumentFromReader(body)
-	if err != nil {
-		return nil, err
+	result := pageclass.Classify(pageclass.Page{URL: pageURL, FinalURL: resp.FinalURL, Status: resp.StatusCode, Doc: doc, Items: names})
+	if result.Kind.Blocking() {
+		return nil, &pageclass.Error{Result: result}
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("%s returned status %d", page, resp.StatusCode)
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	if result.Kind != pageclass.Content {
+		s.debugLog("%s shows %s: %s", page, result.Kind.Describe(), strings.Join(result.Evidence, "; "))
 	}
+	return employees, nil
+}
 
+// extractEmployeesFromDocument extracts Indonesian employees from a page using efficient name lookup.
+// It also returns how many names the selectors found, Indonesian or not.
+func (s *LinkedInScraper) extractEmployeesFromDocument(pageURL string, doc *goquery.Document) ([]Employee, int) {
 	var employees []Employee
 	processedNames := make(map[string]bool)
 
-	// Multiple selectors for finding employee names
-	nameSelectors := []string{
-		".org-people-profile-card__profile-title",
-		".profile-card__title",
-		".member-name",
-		".name",
-		"[data-anonymize='person-name']",
-		".entity-result__title-text a span[aria-hidden='true']",
-		".app-aware-link span[aria-hidden='true']",
-		".actor-name",
-		".search-result__result-link h3 span[aria-hidden='true']",
-	}
+	nameSelectors := s.selectors.Company.EmployeeNames.All()
 
-	for _, selector := range nameSelectors {
+	nameWinner := -1 // first selector that found any name, for selector health
+	names := 0
+	for n, selector := range nameSelectors {
 		doc.Find(selector).Each(func(i int, sel *goquery.Selection) {
 			name := strings.TrimSpace(sel.Text())
+			if name != "" {
+				names++
+				if nameWinner < 0 {
+					nameWinner = n
+				}
+			}
 			if name == "" || processedNames[name] {
 				return
 			}
@@ -682,12 +1320,14 @@ func (s *LinkedInScraper) extractEmployeesFromHTML(body io.Reader) ([]Employee,
 		})
 	}
 
+	s.health.Page(pageURL).Record("company.employee_names", nameWinner)
+
 	// Also check page content with regex for names in text
 	htmlContent := doc.Text()
 	regexEmployees := s.findNamesWithRegex(htmlContent, processedNames)
 	employees = append(employees, regexEmployees...)
 
-	return employees, nil
+	return employees, names
 }
 
 // findNamesWithRegex uses regex to find Indonesian names in text content
@@ -724,12 +1364,7 @@ func (s *LinkedInScraper) findNamesWithRegex(content string, processedNames map[
 // extractPosition tries to extract job position/title for an employee
 func (s *LinkedInScraper) extractPosition(sel *goquery.Selection) string {
 	// Look for position in nearby elements
-	positionSelectors := []string{
-		".org-people-profile-card__profile-info .profile-card__subtitle",
-		".profile-card__subtitle",
-		".member-title",
-		".position",
-	}
+	positionSelectors := s.selectors.Company.EmployeePosition.All()
 
 	parent := sel.Parent()
 	for i := 0; i < 3; i++ { // Check up to 3 levels up
@@ -825,8 +1460,9 @@ func (s *LinkedInScraper) extractCompanyName(companyURL string) string {
 // ================================
 // ORIGINAL FUNCTION: ProcessJobs
 // ================================
-// ProcessJobs processes all jobs and checks for Indonesian employees with progress tracking
-func (s *LinkedInScraper) ProcessJobs(jobs []Job) []Job {
+// ProcessJobs processes all jobs and checks for Indonesian employees with progress tracking.
+// If ctx is cancelled the jobs checked so far are returned together with the context error.
+func (s *LinkedInScraper) ProcessJobs(ctx context.Context, jobs []Job) ([]Job, error) {
 	var processedJobs []Job
 	totalJobs := len(jobs)
 
@@ -836,16 +1472,26 @@ func (s *LinkedInScraper) ProcessJobs(jobs []Job) []Job {
 		log.Printf("[%d/%d] Processing: %s at %s", i+1, totalJobs, job.Title, job.Company)
 
 		startTime := time.Now()
-		hasIndonesian, employees, err := s.CheckIndonesianEmployees(job.CompanyURL)
+		hasIndonesian, employees, cacheInfo, err := s.checkCompanyCached(ctx, job.CompanyURL)
 		duration := time.Since(startTime)
 
+		if cause := stopCause(ctx, err); cause != nil {
+			return processedJobs, cause
+		}
+
 		job.CheckDuration = duration.String()
 		job.EmployeeCount = len(employees)
+		job.CompanyCache = cacheInfo
 
 		if err != nil {
 			log.Printf("❌ Error checking employees for %s: %v", job.Company, err)
 			job.HasIndonesian = false
 			job.IndonesianEmployees = []Employee{}
+			job.CheckError = err.Error()
+			var pageErr *pageclass.Error
+			if errors.As(err, &pageErr) {
+				job.CheckPageKind = pageErr.Kind
+			}
 		} else {
 			job.HasIndonesian = hasIndonesian
 			job.IndonesianEmployees = employees
@@ -862,73 +1508,572 @@ func (s *LinkedInScraper) ProcessJobs(jobs []Job) []Job {
 		// Progress indicator
 		progress := float64(i+1) / float64(totalJobs) * 100
 		log.Printf("Progress: %.1f%% (%d/%d)", progress, i+1, totalJobs)
-
-		time.Sleep(s.delay)
 	}
 
-	return processedJobs
+	return processedJobs, nil
 }
 
 // ========================================
 // NEW ENHANCED FUNCTION: ProcessJobsWithFallback
 // ========================================
-// ProcessJobsWithFallback processes jobs with Indonesian detection but ALWAYS returns results
-func (s *LinkedInScraper) ProcessJobsWithFallback(jobs []Job) []Job {
-	var processedJobs []Job
+// ProcessJobsWithFallback processes jobs with Indonesian detection but ALWAYS returns results.
+// Companies are checked by a bounded pool of workers (see SetConcurrency) that all share the
+// fetcher's rate limit; results come back in the original job order regardless of which
+// check finished first. If the run has to stop, the jobs checked so far are returned
+// together with the cause.
+func (s *LinkedInScraper) ProcessJobsWithFallback(ctx context.Context, jobs []Job) ([]Job, error) {
 	totalJobs := len(jobs)
-	indonesianJobs := 0
+	workers := s.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > totalJobs && totalJobs > 0 {
+		workers = totalJobs
+	}
 
 	log.Printf("🔄 Processing %d jobs for Indonesian employee detection...", totalJobs)
 	log.Printf("💡 Strategy: ALL jobs will be returned (prioritized by Indonesian employees)")
+	if workers > 1 {
+		log.Printf("⚙️  Checking companies with %d parallel workers", workers)
+	}
+
+	results := make([]*Job, totalJobs)
+	indexes := make(chan int)
+
+	var mu sync.Mutex
+	var interrupted error
+	completed := 0
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				log.Printf("[%d/%d] Processing: %s at %s", i+1, totalJobs, jobs[i].Title, jobs[i].Company)
+				job, err := s.checkJobWithFallback(ctx, jobs[i])
+
+				mu.Lock()
+				if err != nil {
+					if interrupted == nil {
+						interrupted = err
+					}
+				} else {
+					// ALWAYS add the job to results, regardless of Indonesian employees
+					results[i] = &job
+					completed++
+
+					// Progress indicator
+					progress := float64(completed) / float64(totalJobs) * 100
+					log.Printf("Progress: %.1f%% (%d/%d)", progress, completed, totalJobs)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
 
-	for i, job := range jobs {
-		log.Printf("[%d/%d] Processing: %s at %s", i+1, totalJobs, job.Title, job.Company)
+	for i := range jobs {
+		mu.Lock()
+		stopped := interrupted != nil
+		mu.Unlock()
+		if stopped || ctx.Err() != nil {
+			break
+		}
 
-		startTime := time.Now()
-		hasIndonesian, employees, err := s.CheckIndonesianEmployees(job.CompanyURL)
-		duration := time.Since(startTime)
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(indexes)
+	wg.Wait()
 
-		job.CheckDuration = duration.String()
-		job.EmployeeCount = len(employees)
+	if interrupted == nil && ctx.Err() != nil {
+		interrupted = ctx.Err()
+	}
+
+	// Keep the original search order so reports and JSON are reproducible
+	var processedJobs []Job
+	indonesianJobs := 0
+	for _, job := range results {
+		if job == nil {
+			continue
+		}
+		if job.HasIndonesian {
+			indonesianJobs++
+		}
+		processedJobs = append(processedJobs, *job)
+	}
+
+	// Enhanced summary log
+	log.Printf("\n📊 PROCESSING SUMMARY:")
+	log.Printf("   Total Jobs Processed: %d", len(processedJobs))
+	log.Printf("   🎯 Jobs with Indonesian Employees: %d", indonesianJobs)
+	log.Printf("   💼 Jobs without Indonesian Employees: %d", len(processedJobs)-indonesianJobs)
+	if interrupted != nil {
+		log.Printf("   ⚠️  Stopped early (%v): %d of %d jobs were checked", interrupted, len(processedJobs), totalJobs)
+	} else {
+		log.Printf("   ✅ All jobs included in results for your review!")
+	}
+
+	return processedJobs, interrupted
+}
+
+// checkJobWithFallback runs the three-approach company check for one job as an independent unit.
+// The returned error is only set when the whole run must stop; ordinary check failures are
+// recorded on the job itself.
+func (s *LinkedInScraper) checkJobWithFallback(ctx context.Context, job Job) (Job, error) {
+	startTime := time.Now()
+	hasIndonesian, employees, cacheInfo, err := s.checkCompanyCached(ctx, job.CompanyURL)
+	duration := time.Since(startTime)
+
+	if cause := stopCause(ctx, err); cause != nil {
+		return job, cause
+	}
+
+	job.CheckDuration = duration.String()
+	job.EmployeeCount = len(employees)
+	job.CompanyCache = cacheInfo
+
+	if err != nil {
+		log.Printf("⚠️  Could not check employees for %s: %v (still adding to results)", job.Company, err)
+		job.HasIndonesian = false
+		job.IndonesianEmployees = []Employee{}
+		job.CheckError = err.Error()
+		var pageErr *pageclass.Error
+		if errors.As(err, &pageErr) {
+			job.CheckPageKind = pageErr.Kind
+		}
+	} else {
+		job.HasIndonesian = hasIndonesian
+		job.IndonesianEmployees = employees
+
+		if hasIndonesian {
+			log.Printf("✅ Found %d Indonesian employees at %s", len(employees), job.Company)
+		} else {
+			log.Printf("➖ No Indonesian employees found at %s (still adding to results)", job.Company)
+		}
+	}
+
+	return job, nil
+}
+
+// EnrichJobs fetches the /jobs/view/<id> page of every job and fills job.Details.
+// Requests go through the scraper's fetcher, so they share the run's pacing, budget and
+// robots.txt rules. A page that cannot be fetched or parsed is recorded on the job;
+// the returned error is only set when the whole run must stop.
+func (s *LinkedInScraper) EnrichJobs(ctx context.Context, jobs []Job) ([]Job, error) {
+	log.Printf("📝 Fetching posting details for %d jobs...", len(jobs))
+
+	enriched := 0
+	for i := range jobs {
+		details, fetched, err := s.fetchJobDetails(ctx, jobs[i])
+		if cause := stopCause(ctx, err); cause != nil {
+			log.Printf("⚠️  Detail fetching stopped after %d of %d jobs: %v", i, len(jobs), cause)
+			return jobs, cause
+		}
 
 		if err != nil {
-			s.debugLog("Error checking employees for %s: %v", job.Company, err)
-			job.HasIndonesian = false
-			job.IndonesianEmployees = []Employee{}
+			log.Printf("⚠️  Could not fetch details for %s at %s: %v", jobs[i].Title, jobs[i].Company, err)
+			details = &JobDetails{FetchError: err.Error()}
 		} else {
-			job.HasIndonesian = hasIndonesian
-			job.IndonesianEmployees = employees
+			enriched++
+			s.debugLog("Details for %s: seniority=%q type=%q posted=%q", jobs[i].Title, details.SeniorityLevel, details.EmploymentType, details.PostedDate)
+		}
+		jobs[i].Details = details
+		if details.posting != nil {
+			s.applyPosting(&jobs[i], details.posting, fetched)
+		}
+		notePosted(&jobs[i], details.PostedDate, fetched)
+		noteSalary(&jobs[i])
+		analyzeDescription(&jobs[i])
+	}
 
-			if hasIndonesian {
-				indonesianJobs++
-				log.Printf("✅ Found %d Indonesian employees at %s", len(employees), job.Company)
-			} else {
-				log.Printf("➖ No Indonesian employees found at %s (still adding to results)", job.Company)
+	log.Printf("✅ Fetched details for %d of %d jobs", enriched, len(jobs))
+	return jobs, nil
+}
+
+// noteSalary fills in the salary from the posting page when the card had none: the
+// page's pay box first, then a pay line in the description
+func noteSalary(job *Job) {
+	if job.Salary != nil || job.Details == nil {
+		return
+	}
+	pay, ok := salary.Parse(job.Details.Salary)
+	if !ok {
+		pay, ok = salary.Find(job.Details.Description)
+	}
+	if ok {
+		job.Salary = &pay
+	}
+}
+
+// analyzeDescription derives the description-based fields of a job from its details
+func analyzeDescription(job *Job) {
+	if job.Details == nil || job.Details.Description == "" {
+		return
+	}
+	visa := analyze.Visa(job.Details.Description)
+	job.Visa = &visa
+	languages := analyze.Languages(job.Details.Description)
+	job.Languages = &languages
+}
+
+// jobFilter drops jobs that fail a user filter before their companies are checked
+type jobFilter struct {
+	name string                       // key in the summary's filtered_out counts
+	keep func(job Job) (bool, string) // whether to keep the job, and why not
+}
+
+// applyFilters returns the jobs that pass every filter and how many each filter removed
+func applyFilters(jobs []Job, filters []jobFilter) ([]Job, map[string]int) {
+	if len(filters) == 0 {
+		return jobs, nil
+	}
+
+	removed := make(map[string]int)
+	var kept []Job
+	for _, job := range jobs {
+		keep := true
+		for _, filter := range filters {
+			ok, reason := filter.keep(job)
+			if !ok {
+				log.Printf("🚫 Skipping %s at %s: %s", job.Title, job.Company, reason)
+				removed[filter.name]++
+				keep = false
+				break
 			}
 		}
+		if keep {
+			kept = append(kept, job)
+		}
+	}
+	return kept, removed
+}
 
-		// ALWAYS add the job to results, regardless of Indonesian employees
-		processedJobs = append(processedJobs, job)
+// cityFilter keeps jobs whose parsed city is one of cities, given in any spelling the gazetteer knows
+func cityFilter(places *geo.Gazetteer, cities []string) jobFilter {
+	wanted := make(map[string]bool)
+	for _, city := range cities {
+		wanted[strings.ToLower(places.CityName(city))] = true
+	}
+	return jobFilter{
+		name: "city",
+		keep: func(job Job) (bool, string) {
+			if job.Place == nil || job.Place.City == "" {
+				return false, fmt.Sprintf("no city recognized in %q", job.Location)
+			}
+			if !wanted[strings.ToLower(job.Place.City)] {
+				return false, fmt.Sprintf("located in %s", job.Place.City)
+			}
+			return true, ""
+		},
+	}
+}
 
-		// Progress indicator
-		progress := float64(i+1) / float64(totalJobs) * 100
-		log.Printf("Progress: %.1f%% (%d/%d)", progress, i+1, totalJobs)
+// remoteOnlyFilter keeps jobs whose workplace type is remote
+func remoteOnlyFilter() jobFilter {
+	return jobFilter{
+		name: "remote_only",
+		keep: func(job Job) (bool, string) {
+			if job.WorkplaceType != geo.Remote {
+				if job.WorkplaceType == geo.Unknown {
+					return false, "workplace type unknown"
+				}
+				return false, fmt.Sprintf("workplace type is %s", job.WorkplaceType)
+			}
+			return true, ""
+		},
+	}
+}
+
+// maxAgeFilter keeps jobs posted within maxAge of now. Jobs without a posted time are
+// kept, since an unknown age says nothing about the posting.
+func maxAgeFilter(maxAge time.Duration, now time.Time) jobFilter {
+	return jobFilter{
+		name: "too_old",
+		keep: func(job Job) (bool, string) {
+			if job.PostedAt == nil {
+				return true, ""
+			}
+			if age := now.Sub(*job.PostedAt); age > maxAge {
+				return false, fmt.Sprintf("posted %s (%.0f days ago)", posted.Format(*job.PostedAt, job.PostedPrecision), age.Hours()/24)
+			}
+			return true, ""
+		},
+	}
+}
 
-		time.Sleep(s.delay)
+// minSalaryFilter keeps jobs whose annual pay range reaches min in the report currency.
+// Jobs without a salary, or in a currency missing from the rate table, are kept.
+func minSalaryFilter(min float64, currency string) jobFilter {
+	return jobFilter{
+		name: "below_min_salary",
+		keep: func(job Job) (bool, string) {
+			if job.Salary == nil || job.Salary.Normalized == nil {
+				return true, ""
+			}
+			if job.Salary.Normalized.Max < min {
+				return false, fmt.Sprintf("pays up to %s a year", salary.FormatAmount(job.Salary.Normalized.Max, currency))
+			}
+			return true, ""
+		},
 	}
+}
 
-	// Enhanced summary log
-	log.Printf("\n📊 PROCESSING SUMMARY:")
-	log.Printf("   Total Jobs Processed: %d", totalJobs)
-	log.Printf("   🎯 Jobs with Indonesian Employees: %d", indonesianJobs)
-	log.Printf("   💼 Jobs without Indonesian Employees: %d", totalJobs-indonesianJobs)
-	log.Printf("   ✅ All jobs included in results for your review!")
+// sortNewestFirst orders jobs by posted time, newest first, with undated jobs last
+func sortNewestFirst(jobs []Job) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].PostedAt == nil || jobs[j].PostedAt == nil {
+			return jobs[i].PostedAt != nil && jobs[j].PostedAt == nil
+		}
+		return jobs[i].PostedAt.After(*jobs[j].PostedAt)
+	})
+}
 
-	return processedJobs
+// newOnlyFilter keeps jobs that earlier runs of the same search did not list
+func newOnlyFilter() jobFilter {
+	return jobFilter{
+		name: "seen_before",
+		keep: func(job Job) (bool, string) {
+			if !job.New {
+				return false, "already listed by an earlier run"
+			}
+			return true, ""
+		},
+	}
+}
+
+// requiredLanguageFilter drops jobs whose description requires one of the given languages.
+// Jobs without a description are kept, since nothing is known about them.
+func requiredLanguageFilter(codes []string) jobFilter {
+	return jobFilter{
+		name: "required_language",
+		keep: func(job Job) (bool, string) {
+			if job.Languages == nil {
+				return true, ""
+			}
+			for _, code := range codes {
+				if job.Languages.Requires(code) {
+					return false, fmt.Sprintf("requires %s", analyze.LanguageDisplayNames[code])
+				}
+			}
+			return true, ""
+		},
+	}
+}
+
+// fetchJobDetails loads and parses the posting page of one job
+func (s *LinkedInScraper) fetchJobDetails(ctx context.Context, job Job) (*JobDetails, time.Time, error) {
+	viewURL := job.JobURL
+	if job.ID != "" {
+		viewURL = linkedinurl.JobURL(job.ID)
+	}
+	if linkedinurl.JobIDFromURL(viewURL) == "" {
+		return nil, time.Time{}, fmt.Errorf("no job ID or posting URL")
+	}
+
+	resp, err := s.makeRequest(ctx, viewURL)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, time.Time{}, fmt.Errorf("posting page returned status %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse posting page: %v", err)
+	}
+
+	details := s.extractJobDetails(doc, postingFor(structured.Find(doc), job.ID))
+	if *details == (JobDetails{}) {
+		return nil, time.Time{}, fmt.Errorf("no details found on posting page")
+	}
+	return details, fetchedAt(resp), nil
+}
+
+// blockText returns the text of sel with one line per paragraph, list item or line break,
+// so later analysis can still tell sentences and bullet points apart
+func blockText(sel *goquery.Selection) string {
+	sel = sel.Clone()
+	sel.Find("br").ReplaceWithHtml("\n")
+	sel.Find("p, li, div, h1, h2, h3, h4, h5, h6").AppendHtml("\n")
+
+	var lines []string
+	for _, line := range strings.Split(sel.Text(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// htmlText turns an HTML description from structured data into block text
+func htmlText(fragment string) string {
+	if fragment == "" {
+		return ""
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return ""
+	}
+	return blockText(doc.Find("body"))
+}
+
+// jobCriteriaFields maps the criteria headings on a posting page (English and German) to JobDetails fields
+var jobCriteriaFields = map[string]string{
+	"seniority level":          "seniority",
+	"karrierestufe":            "seniority",
+	"employment type":          "employment",
+	"beschäftigungsverhältnis": "employment",
+	"job function":             "function",
+	"tätigkeitsbereich":        "function",
+	"industries":               "industries",
+	"branchen":                 "industries",
+}
+
+// applicantCountPattern finds the number in an applicant caption such as "Over 200 applicants"
+var applicantCountPattern = regexp.MustCompile(`\d[\d,.]*`)
+
+// extractJobDetails extracts posting details from the page's embedded posting, when it has
+// one, and with the configured selectors for the fields the posting does not give
+func (s *LinkedInScraper) extractJobDetails(doc *goquery.Document, posting *structured.Posting) *JobDetails {
+	details := &JobDetails{}
+
+	if posting != nil {
+		details.Source = posting.Source
+		details.posting = posting
+		details.Description = htmlText(posting.Description)
+		details.EmploymentType = strings.Join(posting.EmploymentType, ", ")
+		details.JobFunction = posting.Function
+		details.Industries = posting.Industry
+		details.PostedDate = posting.DatePosted
+		if posting.Salary != nil {
+			details.Salary = posting.Salary.Raw
+		}
+		s.debugLog("Found posting in %s: %s at %s", posting.Source, posting.Title, posting.Company)
+	}
+
+	descriptionSelectors := s.selectors.Posting.Description.All()
+
+	for _, descSel := range descriptionSelectors {
+		if details.Description != "" {
+			break
+		}
+		description := doc.Find(descSel).First()
+		if description.Length() > 0 {
+			text := blockText(description)
+			if len(text) > 20 { // Basic validation
+				details.Description = text
+				s.debugLog("Found description with selector %s (%d chars)", descSel, len(text))
+				break
+			}
+		}
+	}
+
+	criteriaSelectors := s.selectors.Posting.Criteria.All()
+
+	for _, criteriaSel := range criteriaSelectors {
+		found := false
+		doc.Find(criteriaSel).Each(func(_ int, item *goquery.Selection) {
+			heading := strings.ToLower(strings.TrimSpace(item.Find("h3, [class*='subheader'], dt").First().Text()))
+			value := strings.TrimSpace(item.Find("[class*='criteria-text'], span, dd").First().Text())
+			if heading == "" || value == "" {
+				return
+			}
+
+			var field *string
+			switch jobCriteriaFields[heading] {
+			case "seniority":
+				field = &details.SeniorityLevel
+			case "employment":
+				field = &details.EmploymentType
+			case "function":
+				field = &details.JobFunction
+			case "industries":
+				field = &details.Industries
+			default:
+				return
+			}
+			if *field == "" {
+				*field = value
+			}
+			found = true
+		})
+		if found {
+			s.debugLog("Found job criteria with selector %s", criteriaSel)
+			break
+		}
+	}
+
+	postedSelectors := s.selectors.Posting.Posted.All()
+
+	for _, postedSel := range postedSelectors {
+		if details.PostedDate != "" {
+			break
+		}
+		posted := strings.Join(strings.Fields(doc.Find(postedSel).First().Text()), " ")
+		if posted != "" {
+			details.PostedDate = posted
+			s.debugLog("Found posted date with selector %s: %s", postedSel, posted)
+			break
+		}
+	}
+
+	salarySelectors := s.selectors.Posting.Salary.All()
+
+	for _, salarySel := range salarySelectors {
+		if details.Salary != "" {
+			break
+		}
+		pay := strings.Join(strings.Fields(doc.Find(salarySel).First().Text()), " ")
+		if pay != "" {
+			details.Salary = pay
+			s.debugLog("Found salary with selector %s: %s", salarySel, pay)
+			break
+		}
+	}
+
+	applicantSelectors := s.selectors.Posting.Applicants.All()
+
+	for _, applicantSel := range applicantSelectors {
+		applicants := strings.Join(strings.Fields(doc.Find(applicantSel).First().Text()), " ")
+		if applicants == "" {
+			continue
+		}
+		details.Applicants = applicants
+		if match := applicantCountPattern.FindString(applicants); match != "" {
+			digits := strings.NewReplacer(",", "", ".", "").Replace(match)
+			details.ApplicantCount, _ = strconv.Atoi(digits)
+		}
+		s.debugLog("Found applicants with selector %s: %s", applicantSel, applicants)
+		break
+	}
+
+	return details
+}
+
+// RunInfo carries run-level metadata that is written into the results summary
+type RunInfo struct {
+	Partial          bool              // the run stopped before every job was checked
+	PartialReason    string            // why the run stopped early
+	Unchecked        bool              // the run stopped before any company was checked
+	RequestsSpent    int               // requests let through the rate limiter
+	RateLimitWait    time.Duration     // total time spent waiting for the rate limiter
+	RobotsDisallowed []string          // URLs skipped because robots.txt disallows them
+	Blocked          bool              // the circuit breaker or a login wall, challenge or block stopped the run
+	BudgetExhausted  bool              // --max-requests ran out before the run was done
+	Query            *SearchQuery      // the search the results came from
+	Queries          []QueryRef        // every keyword/location combination searched, when there were several
+	Search           SearchStats       // pagination and deduplication counters
+	Selectors        *selectors.Report // which selectors matched, with drift warnings
+	Filtered         map[string]int    // jobs removed per user filter
+	History          *history.Report   // postings that appeared and disappeared since the last run
 }
 
 // SaveResults saves the results to a JSON file with better formatting
-func SaveResults(jobs []Job, filename string) error {
+func SaveResults(jobs []Job, filename string, run RunInfo) error {
 	// Create a summary
 	summary := map[string]interface{}{
 		"total_jobs":                 len(jobs),
@@ -937,17 +2082,95 @@ func SaveResults(jobs []Job, filename string) error {
 		"generated_at":               time.Now().Format("2006-01-02 15:04:05"),
 	}
 
+	if run.Partial {
+		summary["partial"] = true
+		summary["partial_reason"] = run.PartialReason
+	}
+	if run.Unchecked {
+		summary["companies_checked"] = false
+	}
+	if run.Blocked {
+		summary["blocked"] = true
+	}
+	if run.BudgetExhausted {
+		summary["budget_exhausted"] = true
+	}
+
+	summary["requests_spent"] = run.RequestsSpent
+	summary["rate_limit_wait"] = run.RateLimitWait.Round(time.Millisecond).String()
+	summary["pages_fetched"] = run.Search.PagesFetched
+	summary["duplicates_removed"] = run.Search.DuplicatesRemoved
+	if run.Search.Searches > 1 {
+		summary["searches"] = run.Search.Searches
+		summary["query_overlaps"] = run.Search.QueryOverlaps
+	}
+	if run.Search.TotalResults > 0 {
+		summary["total_results_reported"] = run.Search.TotalResults
+	}
+	if len(run.Search.PageKinds) > 0 {
+		summary["search_page_kinds"] = run.Search.PageKinds
+	}
+	if len(run.Filtered) > 0 {
+		summary["filtered_out"] = run.Filtered
+	}
+	summary["robots_disallowed"] = len(run.RobotsDisallowed)
+	if len(run.RobotsDisallowed) > 0 {
+		summary["robots_disallowed_urls"] = run.RobotsDisallowed
+	}
+
+	visaCounts := map[analyze.VisaStatus]int{}
+	jobsWithSalary, structuredDetails := 0, 0
+	checkPages := map[pageclass.Kind]int{}
 	for _, job := range jobs {
+		if job.CheckPageKind != "" {
+			checkPages[job.CheckPageKind]++
+		}
+		if job.Salary != nil {
+			jobsWithSalary++
+		}
+		if job.Details != nil && job.Details.Source != "" {
+			structuredDetails++
+		}
 		if job.HasIndonesian {
 			summary["jobs_with_indonesians"] = summary["jobs_with_indonesians"].(int) + 1
 			summary["total_indonesian_employees"] = summary["total_indonesian_employees"].(int) + len(job.IndonesianEmployees)
 		}
+		if job.Visa != nil {
+			visaCounts[job.Visa.Status]++
+		}
+	}
+	if len(visaCounts) > 0 {
+		summary["visa_sponsorship"] = visaCounts
+	}
+	if jobsWithSalary > 0 {
+		summary["jobs_with_salary"] = jobsWithSalary
+	}
+	if structuredDetails > 0 {
+		summary["details_from_structured_data"] = structuredDetails
+	}
+	if len(checkPages) > 0 {
+		summary["company_check_pages"] = checkPages
 	}
 
 	result := map[string]interface{}{
 		"summary": summary,
 		"jobs":    jobs,
 	}
+	if run.Query != nil {
+		result["query"] = run.Query
+	}
+	if len(run.Queries) > 1 {
+		result["queries"] = run.Queries
+	}
+	if run.Selectors != nil {
+		result["selector_health"] = run.Selectors
+		summary["selector_warnings"] = len(run.Selectors.Warnings)
+	}
+	if run.History != nil {
+		result["history"] = run.History
+		summary["new_postings"] = len(run.History.Appeared)
+		summary["disappeared_postings"] = len(run.History.Disappeared)
+	}
 
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
@@ -986,55 +2209,278 @@ func printResults(jobs []Job) {
 	sortedJobs := make([]Job, len(jobs))
 	copy(sortedJobs, jobs)
 
-	for i := 0; i < len(sortedJobs)-1; i++ {
-		for j := i + 1; j < len(sortedJobs); j++ {
-			if !sortedJobs[i].HasIndonesian && sortedJobs[j].HasIndonesian {
-				sortedJobs[i], sortedJobs[j] = sortedJobs[j], sortedJobs[i]
-			}
-		}
+	sort.SliceStable(sortedJobs, func(i, j int) bool {
+		return sortedJobs[i].HasIndonesian && !sortedJobs[j].HasIndonesian
+	})
+
+	for i, job := range sortedJobs {
+		fmt.Printf("\n%d. %s\n", i+1, job.Title)
+		fmt.Printf("   🏢 Company: %s\n", job.Company)
+		fmt.Printf("   📍 Location: %s\n", locationLabel(job))
+		fmt.Printf("   🔗 Job URL: %s\n", job.JobURL)
+		printPosted(job)
+		printSalary(job)
+		printNew(job)
+		printQueries(job)
+		printJobDetails(job)
+		fmt.Printf("   🇮🇩 Indonesian Employees: %v (%d found)\n", job.HasIndonesian, len(job.IndonesianEmployees))
+		if job.CheckError != "" {
+			fmt.Printf("   ⚠️  Check failed: %s\n", job.CheckError)
+		}
+
+		if len(job.IndonesianEmployees) > 0 {
+			fmt.Printf("   👥 Indonesian Staff:\n")
+			for _, emp := range job.IndonesianEmployees {
+				confidenceStr := fmt.Sprintf("%.0f%%", emp.Confidence*100)
+				fmt.Printf("      • %s", emp.Name)
+				if emp.Position != "" {
+					fmt.Printf(" (%s)", emp.Position)
+				}
+				fmt.Printf(" [Confidence: %s]\n", confidenceStr)
+				if len(emp.MatchReasons) > 0 {
+					fmt.Printf("        Reasons: %s\n", strings.Join(emp.MatchReasons, ", "))
+				}
+			}
+		}
+
+		if job.HasIndonesian {
+			fmt.Printf("   ⭐ HIGHLY RECOMMENDED: This company has Indonesian employees!\n")
+		}
+
+		fmt.Printf("   ⏱️  Check Duration: %s\n", job.CheckDuration)
+		printCacheInfo(job)
+		fmt.Println(strings.Repeat("-", 80))
+	}
+
+	// Print top recommendations
+	if jobsWithIndonesians > 0 {
+		fmt.Printf("\n🎯 TOP RECOMMENDATIONS (Companies with Indonesian Employees):\n")
+		fmt.Println(strings.Repeat("-", 60))
+		rank := 1
+		for _, job := range sortedJobs {
+			if job.HasIndonesian {
+				fmt.Printf("%d. %s at %s (%d Indonesian employees)\n", rank, job.Title, job.Company, len(job.IndonesianEmployees))
+				rank++
+			}
+		}
+	}
+}
+
+// printJobDetails prints the posting details fetched with --details
+func printJobDetails(job Job) {
+	if job.Details == nil {
+		return
+	}
+	if job.Details.FetchError != "" {
+		fmt.Printf("   📝 Details: not available - %s\n", job.Details.FetchError)
+		return
+	}
+
+	var facts []string
+	for _, fact := range []string{job.Details.SeniorityLevel, job.Details.EmploymentType, job.Details.JobFunction, job.Details.Industries} {
+		if fact != "" {
+			facts = append(facts, fact)
+		}
+	}
+	if len(facts) > 0 {
+		fmt.Printf("   📝 Details: %s\n", strings.Join(facts, " | "))
+	}
+	if job.Details.PostedDate != "" || job.Details.Applicants != "" {
+		fmt.Printf("   🗓️  Posted: %s", job.Details.PostedDate)
+		if job.Details.Applicants != "" {
+			fmt.Printf(" (%s)", job.Details.Applicants)
+		}
+		fmt.Println()
+	}
+}
+
+// printVisa prints the visa sponsorship / relocation verdict and the sentence it is based on
+func printVisa(job Job) {
+	if job.Visa == nil {
+		return
+	}
+	switch job.Visa.Status {
+	case analyze.VisaSupported:
+		fmt.Printf("   🛂 Visa/Relocation: SUPPORTED (%s)\n", job.Visa.Topic)
+	case analyze.VisaNotSupported:
+		fmt.Printf("   🛂 Visa/Relocation: NOT SUPPORTED (%s)\n", job.Visa.Topic)
+	default:
+		fmt.Printf("   🛂 Visa/Relocation: unknown - not mentioned in the description\n")
+	}
+	if job.Visa.Evidence != "" {
+		fmt.Printf("      \"%s\"\n", job.Visa.Evidence)
+	}
+}
+
+// printLanguages prints the language requirements found in the description
+func printLanguages(job Job) {
+	if job.Languages == nil {
+		return
+	}
+
+	var parts []string
+	for _, req := range job.Languages.Requirements {
+		part := analyze.LanguageDisplayNames[req.Language]
+		if req.Level != "" {
+			part += " " + req.Level
+		}
+		if req.Required {
+			part += " (required)"
+		} else {
+			part += " (a plus)"
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "none stated")
+	}
+
+	fmt.Printf("   🗣️  Languages: %s", strings.Join(parts, ", "))
+	if name := analyze.LanguageDisplayNames[job.Languages.PostingLanguage]; name != "" {
+		fmt.Printf(" | posting written in %s", name)
+	}
+	fmt.Println()
+}
+
+// locationLabel shows the parsed place when known, with the workplace type
+func locationLabel(job Job) string {
+	label := job.Location
+	if job.Place != nil {
+		label = job.Place.String()
+	}
+	if job.WorkplaceType != geo.Unknown {
+		label += " · " + string(job.WorkplaceType)
+	}
+	return label
+}
+
+// printPosted prints when the job was posted, as exactly as it is known
+func printPosted(job Job) {
+	if job.PostedAt == nil {
+		return
+	}
+	days := int(time.Since(*job.PostedAt).Hours() / 24)
+	switch {
+	case days <= 0:
+		fmt.Printf("   🕒 Posted: %s (today)\n", posted.Format(*job.PostedAt, job.PostedPrecision))
+	case days == 1:
+		fmt.Printf("   🕒 Posted: %s (1 day ago)\n", posted.Format(*job.PostedAt, job.PostedPrecision))
+	default:
+		fmt.Printf("   🕒 Posted: %s (%d days ago)\n", posted.Format(*job.PostedAt, job.PostedPrecision), days)
+	}
+}
+
+// printSalary prints the pay as stated and its annual amount in the report currency
+func printSalary(job Job) {
+	if job.Salary == nil {
+		return
+	}
+	fmt.Printf("   💰 Salary: %s", job.Salary)
+	if job.Salary.PeriodGuessed {
+		fmt.Printf(" (period guessed)")
+	}
+	if n := job.Salary.Normalized; n != nil && (n.Currency != job.Salary.Currency || job.Salary.Period != salary.Yearly) {
+		fmt.Printf(" ≈ %s a year", salaryRange(*n))
+	}
+	fmt.Println()
+}
+
+// salaryRange formats an annual range, collapsing equal ends
+func salaryRange(a salary.Amount) string {
+	if a.Min == a.Max {
+		return salary.FormatAmount(a.Min, a.Currency)
+	}
+	return salary.FormatAmount(a.Min, a.Currency) + " – " + salary.FormatAmount(a.Max, a.Currency)
+}
+
+// printSalaryTable lists the jobs that state a salary, with the annual amount in the report currency
+func printSalaryTable(jobs []Job, currency string) {
+	var rows []Job
+	for _, job := range jobs {
+		if job.Salary != nil {
+			rows = append(rows, job)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 100))
+	fmt.Printf("💰 SALARIES (%d of %d jobs state one; annual, in %s)\n", len(rows), len(jobs), currency)
+	fmt.Println(strings.Repeat("=", 100))
+	fmt.Printf("%-36s %-26s %-16s %s\n", "JOB", "COMPANY", "POSTED", "ANNUAL SALARY")
+	for _, job := range rows {
+		annual := "no rate for " + job.Salary.Currency
+		if job.Salary.Normalized != nil {
+			annual = salaryRange(*job.Salary.Normalized)
+		}
+		postedOn := "-"
+		if job.PostedAt != nil {
+			postedOn = posted.Format(*job.PostedAt, job.PostedPrecision)
+		}
+		fmt.Printf("%-36s %-26s %-16s %s\n", truncate(job.Title, 35), truncate(job.Company, 25), postedOn, annual)
+	}
+}
+
+// truncate shortens text to at most n characters for table columns
+func truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// printNew marks jobs that earlier runs of the same search did not list
+func printNew(job Job) {
+	if job.New {
+		fmt.Printf("   🆕 NEW since the last run\n")
 	}
+}
 
-	for i, job := range sortedJobs {
-		fmt.Printf("\n%d. %s\n", i+1, job.Title)
-		fmt.Printf("   🏢 Company: %s\n", job.Company)
-		fmt.Printf("   📍 Location: %s\n", job.Location)
-		fmt.Printf("   🔗 Job URL: %s\n", job.JobURL)
-		fmt.Printf("   🇮🇩 Indonesian Employees: %v (%d found)\n", job.HasIndonesian, len(job.IndonesianEmployees))
+// printQueries prints which searches of a multi-search run found the job
+func printQueries(job Job) {
+	if len(job.Queries) == 0 {
+		return
+	}
+	var refs []string
+	for _, ref := range job.Queries {
+		refs = append(refs, ref.String())
+	}
+	fmt.Printf("   🔎 Found by: %s\n", strings.Join(refs, "; "))
+}
 
-		if len(job.IndonesianEmployees) > 0 {
-			fmt.Printf("   👥 Indonesian Staff:\n")
-			for _, emp := range job.IndonesianEmployees {
-				confidenceStr := fmt.Sprintf("%.0f%%", emp.Confidence*100)
-				fmt.Printf("      • %s", emp.Name)
-				if emp.Position != "" {
-					fmt.Printf(" (%s)", emp.Position)
-				}
-				fmt.Printf(" [Confidence: %s]\n", confidenceStr)
-				if len(emp.MatchReasons) > 0 {
-					fmt.Printf("        Reasons: %s\n", strings.Join(emp.MatchReasons, ", "))
-				}
-			}
-		}
+// printHistoryReport prints the postings that appeared and disappeared since the last run
+func printHistoryReport(report history.Report) {
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Printf("🗂️  CHANGES SINCE LAST RUN (%s)\n", report.Search)
+	fmt.Println(strings.Repeat("=", 80))
 
-		if job.HasIndonesian {
-			fmt.Printf("   ⭐ HIGHLY RECOMMENDED: This company has Indonesian employees!\n")
-		}
+	if report.FirstRun {
+		fmt.Printf("ℹ️  First run of this search - all %d postings are new. Later runs will show what changed.\n", len(report.Appeared))
+		return
+	}
 
-		fmt.Printf("   ⏱️  Check Duration: %s\n", job.CheckDuration)
-		fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("   Previous run: %s\n", report.PreviousRun.Format("2006-01-02 15:04"))
+	fmt.Printf("   🆕 New postings: %d\n", len(report.Appeared))
+	for _, posting := range report.Appeared {
+		fmt.Printf("      • %s at %s\n", posting.Title, posting.Company)
+	}
+	fmt.Printf("   👋 No longer listed: %d\n", len(report.Disappeared))
+	for _, posting := range report.Disappeared {
+		fmt.Printf("      • %s at %s (first seen %s)\n", posting.Title, posting.Company, posting.FirstSeen.Format("2006-01-02"))
 	}
+}
 
-	// Print top recommendations
-	if jobsWithIndonesians > 0 {
-		fmt.Printf("\n🎯 TOP RECOMMENDATIONS (Companies with Indonesian Employees):\n")
-		fmt.Println(strings.Repeat("-", 60))
-		rank := 1
-		for _, job := range sortedJobs {
-			if job.HasIndonesian {
-				fmt.Printf("%d. %s at %s (%d Indonesian employees)\n", rank, job.Title, job.Company, len(job.IndonesianEmployees))
-				rank++
-			}
-		}
+// printCacheInfo prints whether the company result was fresh or reused, and how old it is
+func printCacheInfo(job Job) {
+	if job.CompanyCache == nil {
+		return
+	}
+	if job.CompanyCache.Status == string(companycache.Cached) {
+		fmt.Printf("   🗃️  Company Result: cached (checked %s ago, %s)\n", job.CompanyCache.Age, job.CompanyCache.CheckedAt)
+	} else {
+		fmt.Printf("   🗃️  Company Result: fresh (checked %s)\n", job.CompanyCache.CheckedAt)
 	}
 }
 
@@ -1072,13 +2518,9 @@ func printResultsEnhanced(jobs []Job) {
 	sortedJobs := make([]Job, len(jobs))
 	copy(sortedJobs, jobs)
 
-	for i := 0; i < len(sortedJobs)-1; i++ {
-		for j := i + 1; j < len(sortedJobs); j++ {
-			if !sortedJobs[i].HasIndonesian && sortedJobs[j].HasIndonesian {
-				sortedJobs[i], sortedJobs[j] = sortedJobs[j], sortedJobs[i]
-			}
-		}
-	}
+	sort.SliceStable(sortedJobs, func(i, j int) bool {
+		return sortedJobs[i].HasIndonesian && !sortedJobs[j].HasIndonesian
+	})
 
 	// Print PRIORITY jobs with Indonesian employees first
 	if jobsWithIndonesians > 0 {
@@ -1093,8 +2535,15 @@ func printResultsEnhanced(jobs []Job) {
 				priorityCount++
 				fmt.Printf("\n🌟 PRIORITY #%d: %s\n", priorityCount, job.Title)
 				fmt.Printf("   🏢 Company: %s\n", job.Company)
-				fmt.Printf("   📍 Location: %s\n", job.Location)
+				fmt.Printf("   📍 Location: %s\n", locationLabel(job))
 				fmt.Printf("   🔗 Job URL: %s\n", job.JobURL)
+				printPosted(job)
+				printSalary(job)
+				printNew(job)
+				printQueries(job)
+				printJobDetails(job)
+				printVisa(job)
+				printLanguages(job)
 				fmt.Printf("   🇮🇩 Indonesian Employees Found: %d\n", len(job.IndonesianEmployees))
 
 				if len(job.IndonesianEmployees) > 0 {
@@ -1111,6 +2560,7 @@ func printResultsEnhanced(jobs []Job) {
 
 				fmt.Printf("   ⭐ STRATEGY: Mention Indonesian connection in your application!\n")
 				fmt.Printf("   ⏱️  Detection Time: %s\n", job.CheckDuration)
+				printCacheInfo(job)
 				fmt.Println(strings.Repeat("-", 60))
 			}
 		}
@@ -1130,12 +2580,24 @@ func printResultsEnhanced(jobs []Job) {
 				altCount++
 				fmt.Printf("\n💼 ALTERNATIVE #%d: %s\n", altCount, job.Title)
 				fmt.Printf("   🏢 Company: %s\n", job.Company)
-				fmt.Printf("   📍 Location: %s\n", job.Location)
+				fmt.Printf("   📍 Location: %s\n", locationLabel(job))
 				fmt.Printf("   🔗 Job URL: %s\n", job.JobURL)
-				fmt.Printf("   🔍 Indonesian Check: No employees detected in our search\n")
+				printPosted(job)
+				printSalary(job)
+				printNew(job)
+				printQueries(job)
+				printJobDetails(job)
+				printVisa(job)
+				printLanguages(job)
+				if job.CheckError != "" {
+					fmt.Printf("   ⚠️  Indonesian Check: FAILED - %s\n", job.CheckError)
+				} else {
+					fmt.Printf("   🔍 Indonesian Check: No employees detected in our search\n")
+				}
 				fmt.Printf("   💡 TIP: Research company manually or apply with standard approach\n")
 				fmt.Printf("   🚀 OPPORTUNITY: Could be the first Indonesian employee!\n")
 				fmt.Printf("   ⏱️  Detection Time: %s\n", job.CheckDuration)
+				printCacheInfo(job)
 				fmt.Println(strings.Repeat("-", 60))
 			}
 		}
@@ -1175,11 +2637,88 @@ func printResultsEnhanced(jobs []Job) {
 	fmt.Printf("\n📊 SUCCESS METRICS: You now have %d total opportunities with clear prioritization!\n", totalJobs)
 }
 
+// stringList is a flag that may be repeated, collecting every value
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// Process exit statuses of runs that stopped early
+const (
+	exitBlocked = 3 // the circuit breaker or a LinkedIn wall stopped the run
+	exitBudget  = 4 // --max-requests ran out before the run was done
+)
+
 func main() {
-	if len(os.Args) < 3 {
+	os.Exit(run())
+}
+
+// run scrapes and reports, returning the process exit status once every deferred
+// cleanup (such as saving the company cache) has run
+func run() (exitCode int) {
+	fixturesDir := flag.String("fixtures", "", "serve pages from a fixture directory instead of LinkedIn (offline mode)")
+	recordDir := flag.String("record", "", "record every request and response into this directory")
+	replayDir := flag.String("replay", "", "answer every request from a directory written by --record (no network)")
+	retryAttempts := flag.Int("retry-attempts", fetcher.DefaultRetryPolicy().MaxAttempts, "total attempts per request for 429 and 5xx responses")
+	retryBackoff := flag.Duration("retry-backoff", fetcher.DefaultRetryPolicy().BaseDelay, "initial retry backoff, doubled after each attempt (with jitter)")
+	retryMaxWait := flag.Duration("retry-max-wait", fetcher.DefaultRetryPolicy().MaxRetryAfter, "give up instead of waiting when Retry-After asks for longer than this")
+	requestsPerMinute := flag.Float64("rpm", 20, "maximum requests per minute per host (0 = unlimited)")
+	maxRequests := flag.Int("max-requests", 0, "hard cap on total requests in this run (0 = unlimited)")
+	workers := flag.Int("workers", 1, "number of companies to check in parallel (all share the --rpm limit)")
+	companyCachePath := flag.String("company-cache", "cache/companies.json", "file that keeps company results between runs (empty = this run only)")
+	companyCacheTTL := flag.Duration("company-cache-ttl", 7*24*time.Hour, "reuse stored company results younger than this")
+	refreshCompanies := flag.Bool("refresh-companies", false, "ignore stored company results and check every company again")
+	userAgentFlag := flag.String("user-agent", fetcher.DefaultUserAgent, "User-Agent header; its product token is matched against robots.txt")
+	contact := flag.String("contact", "", "contact string (e.g. an email address) appended to the User-Agent")
+	blockThreshold := flag.Int("block-threshold", 3, "stop the run after this many consecutive 999/429 responses")
+	experienceFlag := flag.String("experience", "", "experience levels, comma-separated: internship,entry,associate,mid-senior,director,executive")
+	jobTypeFlag := flag.String("job-type", "", "job types, comma-separated: full-time,part-time,contract,temporary,volunteer,internship,other")
+	workplaceFlag := flag.String("workplace", "", "workplace types, comma-separated: on-site,hybrid,remote")
+	datePostedFlag := flag.String("date-posted", "", "posting age: any, 24h, week or month")
+	sortFlag := flag.String("sort", "", "sort order: relevance (default) or recent")
+	companyIDsFlag := flag.String("company-id", "", "only jobs from these numeric LinkedIn company IDs, comma-separated")
+	fetchDetails := flag.Bool("details", false, "fetch each job's posting page for description, seniority, employment type and more (one extra request per job)")
+	savedSearchName := flag.String("search", "", "run a saved search from the --searches file (no positional arguments needed)")
+	searchesFile := flag.String("searches", "searches.json", "file with saved searches (see searches.example.json)")
+	historyDir := flag.String("history", "cache/history", "directory that remembers the postings each search listed (empty = off)")
+	newOnly := flag.Bool("new-only", false, "only check and report jobs that earlier runs of the same search did not list")
+	var extraLocations, extraKeywords stringList
+	flag.Var(&extraLocations, "location", "also search this location (repeatable); every location is searched with every keyword")
+	flag.Var(&extraKeywords, "keywords", "also search these keywords (repeatable); every keyword is searched in every location")
+	cityFlag := flag.String("city", "", "only jobs in these cities, comma-separated (local spellings like München work)")
+	remoteOnly := flag.Bool("remote-only", false, "only remote jobs (also asks LinkedIn for remote jobs unless --workplace is set)")
+	minSalary := flag.Float64("min-salary", 0, "only jobs whose annual salary range reaches this amount in --salary-currency (jobs without a salary are kept)")
+	salaryCurrency := flag.String("salary-currency", "EUR", "currency for comparing and reporting salaries (must be in --exchange-rates)")
+	exchangeRatesFile := flag.String("exchange-rates", "data/exchange_rates.txt", "offline exchange rate table used to convert salaries")
+	maxAgeFlag := flag.String("max-age", "", "only jobs posted within this age, e.g. 24h, 7d or 2w (jobs without a posted time are kept)")
+	newestFirst := flag.Bool("newest-first", false, "list jobs newest first in the reports and results file")
+	excludeLanguagesFlag := flag.String("exclude-required-language", "", "drop jobs whose description requires one of these languages, comma-separated codes or names (e.g. de,nl); implies --details")
+	selectorsFile := flag.String("selectors", "selectors.json", "selector file laid over the built-in CSS selectors (used when it exists; see selectors/default.json)")
+	checkSelectors := flag.Bool("check-selectors", false, "validate the selector file and exit")
+	maxDuration := flag.Duration("max-duration", 0, "stop the run after this long and save partial results (e.g. 30m)")
+	flag.Parse()
+	args := flag.Args()
+
+	// Validate selectors before anything else, so a broken selector file fails fast
+	pageSelectors, err := loadSelectors(*selectorsFile, flagPassed("selectors"))
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *checkSelectors {
+		fmt.Printf("✅ Selectors OK: %s (version %d, updated %s)\n", pageSelectors.Source(), pageSelectors.Version, pageSelectors.Updated)
+		return 0
+	}
+
+	if len(args) < 2 && *savedSearchName == "" {
 		fmt.Println("🇮🇩 Enhanced LinkedIn Indonesian Employee Job Scraper v2.0")
 		fmt.Println("===========================================================")
-		fmt.Println("Usage: go run main.go <country> <job_title> [limit]")
+		fmt.Println("Usage: go run main.go [flags] <country> <job_title> [limit]")
 		fmt.Println("Example: go run main.go \"Germany\" \"software engineer\" 25")
 		fmt.Println("")
 		fmt.Println("🆕 ENHANCED FEATURES:")
@@ -1193,18 +2732,117 @@ func main() {
 		fmt.Println("🐛 DEBUG MODE:")
 		fmt.Println("DEBUG=true go run main.go \"Germany\" \"software engineer\" 5")
 		fmt.Println("")
+		fmt.Println("🧪 OFFLINE MODE:")
+		fmt.Println("go run main.go --fixtures testdata/fixtures \"Germany\" \"golang developer\" 5")
+		fmt.Println("go run main.go --record sessions/germany \"Germany\" \"golang developer\" 5")
+		fmt.Println("go run main.go --replay sessions/germany \"Germany\" \"golang developer\" 5")
+		fmt.Println("")
 		fmt.Println("💡 STRATEGY MODES:")
 		fmt.Println("• Enhanced strategy (default): Always returns results with prioritization")
 		fmt.Println("• Original strategy: Set useEnhancedStrategy = false in code")
-		os.Exit(1)
+		fmt.Println("")
+		fmt.Println("⚙️  FLAGS:")
+		flag.PrintDefaults()
+		return 1
 	}
 
-	country := os.Args[1]
-	jobTitle := os.Args[2]
+	var country, jobTitle string
 	limit := 25
+	var saved SavedSearch
+
+	if *savedSearchName != "" {
+		if len(args) > 0 {
+			log.Fatalf("--search %s takes its keywords and locations from %s; drop the positional arguments", *savedSearchName, *searchesFile)
+		}
+		var err error
+		saved, err = loadSavedSearch(*searchesFile, *savedSearchName)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		jobTitle, country = saved.Keywords[0], saved.Locations[0]
+		extraKeywords = append(append(stringList{}, saved.Keywords[1:]...), extraKeywords...)
+		extraLocations = append(append(stringList{}, saved.Locations[1:]...), extraLocations...)
+		if saved.Limit > 0 {
+			limit = saved.Limit
+		}
+	} else {
+		country = args[0]
+		jobTitle = args[1]
+		if len(args) > 2 {
+			fmt.Sscanf(args[2], "%d", &limit)
+		}
+	}
+
+	// Search filter flags win over the saved search's filters
+	query := saved.Filters
+	query.Keywords, query.Location, query.Limit = jobTitle, country, limit
+	if flagPassed("experience") || *savedSearchName == "" {
+		query.ExperienceLevels = splitList(*experienceFlag)
+	}
+	if flagPassed("job-type") || *savedSearchName == "" {
+		query.JobTypes = splitList(*jobTypeFlag)
+	}
+	if flagPassed("workplace") || *savedSearchName == "" {
+		query.WorkplaceTypes = splitList(*workplaceFlag)
+	}
+	if flagPassed("date-posted") || *savedSearchName == "" {
+		query.DatePosted = strings.ToLower(*datePostedFlag)
+	}
+	if flagPassed("sort") || *savedSearchName == "" {
+		query.SortBy = strings.ToLower(*sortFlag)
+	}
+	if flagPassed("company-id") || *savedSearchName == "" {
+		query.CompanyIDs = splitList(*companyIDsFlag)
+	}
+	if *remoteOnly && len(query.WorkplaceTypes) == 0 {
+		query.WorkplaceTypes = []string{"remote"}
+	}
+	var maxAge time.Duration
+	if *maxAgeFlag != "" {
+		var err error
+		if maxAge, err = posted.ParseAge(*maxAgeFlag); err != nil {
+			log.Fatalf("Invalid --max-age: %v", err)
+		}
+		// Let LinkedIn drop older postings too, with the smallest window that covers --max-age
+		if query.DatePosted == "" {
+			switch {
+			case maxAge <= 24*time.Hour:
+				query.DatePosted = "24h"
+			case maxAge <= 7*24*time.Hour:
+				query.DatePosted = "week"
+			case maxAge <= 30*24*time.Hour:
+				query.DatePosted = "month"
+			}
+		}
+	}
+	if err := query.Validate(); err != nil {
+		log.Fatalf("Invalid search: %v", err)
+	}
+
+	rates, err := salary.LoadRates(*exchangeRatesFile)
+	if err != nil {
+		log.Fatalf("Failed to load exchange rates: %v", err)
+	}
+	*salaryCurrency = strings.ToUpper(*salaryCurrency)
+	if !rates.Knows(*salaryCurrency) {
+		log.Fatalf("Invalid --salary-currency %s: %s has rates for %s", *salaryCurrency, *exchangeRatesFile, strings.Join(rates.Currencies(), ", "))
+	}
 
-	if len(os.Args) > 3 {
-		fmt.Sscanf(os.Args[3], "%d", &limit)
+	var filters []jobFilter
+	if *minSalary > 0 {
+		filters = append(filters, minSalaryFilter(*minSalary, *salaryCurrency))
+	}
+	if excluded := splitList(*excludeLanguagesFlag); len(excluded) > 0 {
+		var codes []string
+		for _, value := range excluded {
+			code, ok := analyze.LanguageCode(value)
+			if !ok {
+				log.Fatalf("Invalid --exclude-required-language: unknown language %q", value)
+			}
+			codes = append(codes, code)
+		}
+		filters = append(filters, requiredLanguageFilter(codes))
+		*fetchDetails = true // the filter reads the job descriptions
 	}
 
 	// Strategy selection - you can change this
@@ -1212,7 +2850,20 @@ func main() {
 
 	fmt.Println("🇮🇩 Enhanced LinkedIn Indonesian Employee Job Scraper")
 	fmt.Println("====================================================")
-	fmt.Printf("🔍 Searching for '%s' jobs in %s (limit: %d)...\n", jobTitle, country, limit)
+	queries := query.Expand(extraKeywords, extraLocations)
+	if len(queries) > 1 {
+		fmt.Printf("🔍 Searching %d keyword/location combinations (limit: %d jobs in total)...\n", len(queries), limit)
+		for _, q := range queries {
+			fmt.Printf("   • %s\n", q.Ref())
+		}
+	} else {
+		fmt.Printf("🔍 Searching for '%s' jobs in %s (limit: %d)...\n", jobTitle, country, limit)
+	}
+	if filters := query.params(false); len(filters) > 2 {
+		filters.Del("keywords")
+		filters.Del("location")
+		fmt.Printf("🎛️  Filters: %s\n", filters.Encode())
+	}
 
 	if useEnhancedStrategy {
 		fmt.Println("🎯 ENHANCED STRATEGY: Find jobs with Indonesian employees + show alternatives")
@@ -1220,47 +2871,287 @@ func main() {
 		fmt.Println("🔍 ORIGINAL STRATEGY: Indonesian employee detection only")
 	}
 
-	if os.Getenv("DEBUG") == "true" || os.Getenv("SCRAPER_DEBUG") == "true" {
+	if debugEnabled() {
 		fmt.Println("🐛 DEBUG MODE ENABLED - Detailed logging activated")
 	}
 
-	scraper, err := NewLinkedInScraper()
+	if *fixturesDir != "" && *replayDir != "" {
+		log.Fatalf("--fixtures and --replay cannot be used together")
+	}
+	if *recordDir != "" && *replayDir != "" {
+		log.Fatalf("--record and --replay cannot be used together")
+	}
+
+	httpFetcher := fetcher.NewHTTPFetcher(30 * time.Second)
+	userAgent := *userAgentFlag
+	if *contact != "" {
+		userAgent = fmt.Sprintf("%s (+contact: %s)", userAgent, *contact)
+	}
+	httpFetcher.SetUserAgent(userAgent)
+
+	var pageFetcher fetcher.Fetcher = httpFetcher
+	if *fixturesDir != "" {
+		fileFetcher, err := fetcher.NewFileFetcher(*fixturesDir)
+		if err != nil {
+			log.Fatalf("Failed to open fixtures: %v", err)
+		}
+		fmt.Printf("🧪 OFFLINE MODE: Serving pages from %s\n", *fixturesDir)
+		pageFetcher = fileFetcher
+	}
+
+	var replayer *fetcher.Replayer
+	if *replayDir != "" {
+		var err error
+		replayer, err = fetcher.NewReplayer(*replayDir)
+		if err != nil {
+			log.Fatalf("Failed to load replay session: %v", err)
+		}
+		fmt.Printf("⏪ REPLAY MODE: Answering requests from %s (no network)\n", *replayDir)
+		pageFetcher = replayer
+
+		// Missing pages fail the run, unless it already fails for another reason
+		defer func() {
+			if reportReplayMisses(replayer) && exitCode == 0 {
+				exitCode = 1
+			}
+		}()
+	}
+
+	if *recordDir != "" {
+		recorder, err := fetcher.NewRecorder(pageFetcher, *recordDir)
+		if err != nil {
+			log.Fatalf("Failed to start recording: %v", err)
+		}
+		fmt.Printf("⏺️  RECORD MODE: Saving every exchange to %s\n", *recordDir)
+		pageFetcher = recorder
+	}
+
+	// Every request, retries included, goes through one scheduler. Offline runs are not
+	// paced unless --rpm is given explicitly.
+	offline := *fixturesDir != "" || *replayDir != ""
+	rpm := *requestsPerMinute
+	if offline && !flagPassed("rpm") {
+		rpm = 0
+	}
+	limiter := fetcher.NewRateLimiter(pageFetcher, rpm, 1, *maxRequests)
+	pageFetcher = limiter
+
+	// robots.txt is checked before a request is paced or counted, so disallowed URLs
+	// cost nothing; Crawl-delay slows live hosts down further, even with --rpm 0
+	var onCrawlDelay func(host string, delay time.Duration)
+	if !offline {
+		onCrawlDelay = func(host string, delay time.Duration) {
+			log.Printf("🐢 %s asks for Crawl-delay %v - pacing requests accordingly", host, delay)
+			limiter.SetCrawlDelay(host, delay)
+		}
+	}
+	robots := fetcher.NewRobotsFetcher(pageFetcher, fetcher.AgentToken(userAgent), onCrawlDelay)
+	pageFetcher = robots
+
+	// The breaker sees every attempt, so a run of 999/429 responses stops the whole run
+	pageFetcher = fetcher.NewCircuitBreaker(pageFetcher, *blockThreshold)
+
+	retryPolicy := fetcher.DefaultRetryPolicy()
+	retryPolicy.MaxAttempts = *retryAttempts
+	retryPolicy.BaseDelay = *retryBackoff
+	retryPolicy.MaxRetryAfter = *retryMaxWait
+	pageFetcher = fetcher.NewRetryFetcher(pageFetcher, retryPolicy, func(format string, args ...interface{}) {
+		if debugEnabled() {
+			log.Printf("[DEBUG] [retry] "+format, args...)
+		}
+	})
+
+	scraper, err := NewLinkedInScraper(pageFetcher)
 	if err != nil {
 		log.Fatalf("Failed to initialize scraper: %v", err)
 	}
+	scraper.SetConcurrency(*workers)
+	scraper.SetSelectors(pageSelectors)
+
+	if cities := splitList(*cityFlag); len(cities) > 0 {
+		filters = append(filters, cityFilter(scraper.places, cities))
+	}
+	if *remoteOnly {
+		filters = append(filters, remoteOnlyFilter())
+	}
+	if maxAge > 0 {
+		filters = append(filters, maxAgeFilter(maxAge, time.Now()))
+	}
+
+	// Offline runs keep company results in memory unless a store is given explicitly
+	cachePath := *companyCachePath
+	if offline && !flagPassed("company-cache") {
+		cachePath = ""
+	}
+	companies, err := companycache.Open(cachePath, *companyCacheTTL, *refreshCompanies)
+	if err != nil {
+		log.Fatalf("Failed to open company cache: %v", err)
+	}
+	scraper.SetCompanyCache(companies)
+	defer func() {
+		if err := companies.Close(); err != nil {
+			log.Printf("❌ Failed to save company cache: %v", err)
+		}
+	}()
+
+	// Ctrl-C or --max-duration stops in-flight work; whatever was checked is still saved
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *maxDuration)
+		defer cancel()
+	}
+	go func() {
+		<-ctx.Done()
+		stop() // a second Ctrl-C terminates immediately
+	}()
+
+	// describe builds the results summary metadata for how the run ended; historyReport
+	// is set once the search has been compared with earlier runs
+	var historyReport *history.Report
+	describe := func(err error) RunInfo {
+		run := runInfo(ctx, err, *maxDuration, limiter, robots)
+		run.Query = &query
+		for _, q := range queries {
+			run.Queries = append(run.Queries, q.Ref())
+		}
+		run.Search = scraper.SearchStats()
+		selectorHealth := scraper.SelectorHealth()
+		run.Selectors = &selectorHealth
+		run.History = historyReport
+		return run
+	}
 
 	// Search for jobs with enhanced fallback strategies
-	jobs, err := scraper.SearchJobs(country, jobTitle, limit)
+	jobs, err := scraper.SearchAll(ctx, queries, limit)
 	if err != nil {
-		log.Fatalf("Failed to search jobs: %v", err)
+		var pageErr *pageclass.Error
+		if cause := stopCause(ctx, err); cause != nil {
+			fmt.Printf("\n⚠️  Search stopped after finding %d jobs (%s) - no companies were checked yet.\n", len(jobs), stopReason(ctx, cause, *maxDuration))
+			run := describe(cause)
+			if len(jobs) > 0 {
+				saveUnchecked(jobs, resultsFilename(useEnhancedStrategy, country, jobTitle), run)
+			} else {
+				printRequestStats(run)
+			}
+			if errors.As(cause, &pageErr) {
+				printDiagnosis(pageErr.Result)
+			}
+			if errors.Is(cause, fetcher.ErrCircuitOpen) {
+				fmt.Println("🛑 LinkedIn is blocking or throttling this client - stopping without working around it.")
+			}
+			if status := stopStatus(run); status != 0 {
+				return status
+			}
+			return 130
+		}
+		if errors.As(err, &pageErr) {
+			// Stop here: checking companies or retrying other searches would hit the same page
+			fmt.Printf("\n❌ Search stopped after finding %d jobs (LinkedIn served %s) - no companies were checked.\n", len(jobs), pageErr.Kind.Describe())
+			run := describe(err)
+			if len(jobs) > 0 {
+				saveUnchecked(jobs, resultsFilename(useEnhancedStrategy, country, jobTitle), run)
+			} else {
+				printRequestStats(run)
+			}
+			if pageErr.Kind == pageclass.UnknownLayout {
+				printSelectorHealth(scraper.SelectorHealth())
+			}
+			printDiagnosis(pageErr.Result)
+			if pageErr.Kind.Blocking() {
+				return exitBlocked
+			}
+			return 1
+		}
+		log.Printf("Failed to search jobs: %v", err)
+		return 1
 	}
 
 	if len(jobs) == 0 {
+		printSelectorHealth(scraper.SelectorHealth())
 		fmt.Println("❌ No jobs found with current search terms.")
-		fmt.Println("\n🔧 ENHANCED TROUBLESHOOTING SUGGESTIONS:")
-		fmt.Println("1. 🎯 Try broader search terms:")
-		fmt.Println("   • 'software engineer' instead of 'golang developer'")
-		fmt.Println("   • 'developer' instead of specific technologies")
-		fmt.Println("   • 'programmer' or 'engineer' for wider results")
-		fmt.Println("")
-		fmt.Println("2. 🗺️  Try specific locations:")
-		fmt.Println("   • 'Berlin, Germany' instead of 'Germany'")
-		fmt.Println("   • 'Amsterdam, Netherlands'")
-		fmt.Println("   • 'London, United Kingdom'")
-		fmt.Println("")
-		fmt.Println("3. 🛡️  LinkedIn may be rate limiting:")
-		fmt.Println("   • Try using a VPN (different location)")
-		fmt.Println("   • Wait 30-60 minutes and retry")
-		fmt.Println("   • Use DEBUG=true for detailed diagnostics")
-		fmt.Println("")
-		fmt.Println("4. 🐛 Enable debug mode for detailed analysis:")
-		fmt.Println("   DEBUG=true go run main.go \"Germany\" \"software engineer\" 5")
-		fmt.Println("")
-		fmt.Println("5. 📊 Check debug output:")
-		fmt.Println("   • Debug HTML files will be saved for analysis")
-		fmt.Println("   • Page structure analysis will show what LinkedIn returned")
-		fmt.Println("   • Response status codes will indicate blocking")
-		return
+		if empty := scraper.SearchStats().LastEmptyPage; empty != nil {
+			printDiagnosis(*empty)
+		} else {
+			fmt.Println("   No search page could be fetched - see the errors above.")
+		}
+		return 0
+	}
+
+	// Compare with earlier runs of the same search before any filter removes jobs. Offline
+	// runs leave the history alone unless --history is given explicitly.
+	historyPath := *historyDir
+	if offline && !flagPassed("history") {
+		historyPath = ""
+	}
+	if historyPath != "" {
+		searchName := *savedSearchName
+		if searchName == "" {
+			var refs []string
+			for _, q := range queries {
+				refs = append(refs, q.Ref().String())
+			}
+			searchName = strings.Join(refs, " + ")
+		}
+
+		runHistory, err := history.Open(historyPath, searchName)
+		if err != nil {
+			log.Printf("Failed to open run history: %v", err)
+			return 1
+		}
+
+		var postings []history.Posting
+		for _, job := range jobs {
+			postings = append(postings, history.Posting{ID: jobKey(job), Title: job.Title, Company: job.Company, JobURL: job.JobURL})
+		}
+		// Only a search that listed every matching posting can tell which ones disappeared
+		stats := scraper.SearchStats()
+		report := runHistory.Update(postings, stats.FailedSearches == 0 && stats.CutOffSearches == 0, time.Now())
+		if err := runHistory.Save(); err != nil {
+			log.Printf("❌ Failed to save run history: %v", err)
+		}
+		historyReport = &report
+
+		for i := range jobs {
+			jobs[i].New = !report.FirstRun && report.IsNew(jobKey(jobs[i]))
+		}
+		log.Printf("🗂️  %d new and %d disappeared postings since the last run of %q", len(report.Appeared), len(report.Disappeared), searchName)
+
+		if *newOnly && !report.FirstRun {
+			filters = append([]jobFilter{newOnlyFilter()}, filters...)
+		}
+	} else if *newOnly {
+		log.Printf("--new-only needs the run history; give a --history directory (offline runs keep none by default)")
+		return 1
+	}
+
+	var detailsErr error
+	if *fetchDetails {
+		// A stop here skips the company checks; the jobs are saved as partial results below
+		jobs, detailsErr = scraper.EnrichJobs(ctx, jobs)
+	}
+
+	for i := range jobs {
+		if jobs[i].Salary != nil && !rates.Normalize(jobs[i].Salary, *salaryCurrency) {
+			log.Printf("⚠️  No exchange rate for %s in %s - salary of %s at %s not converted", jobs[i].Salary.Currency, *exchangeRatesFile, jobs[i].Title, jobs[i].Company)
+		}
+	}
+
+	foundJobs := len(jobs)
+	jobs, filtered := applyFilters(jobs, filters)
+	if detailsErr != nil {
+		run := describe(detailsErr)
+		run.Filtered = filtered
+		saveUnchecked(jobs, resultsFilename(useEnhancedStrategy, country, jobTitle), run)
+		return stopStatus(run)
+	}
+	if len(jobs) == 0 {
+		if historyReport != nil {
+			printHistoryReport(*historyReport)
+		}
+		fmt.Printf("❌ All %d jobs found were removed by your filters: %v\n", foundJobs, filtered)
+		return 0
 	}
 
 	fmt.Printf("✅ Found %d jobs! Now checking for Indonesian employees...\n", len(jobs))
@@ -1268,21 +3159,37 @@ func main() {
 	if useEnhancedStrategy {
 		fmt.Println("💡 Enhanced Strategy: ALL jobs will be included in results")
 		// Use enhanced strategy - always returns results
-		processedJobs := scraper.ProcessJobsWithFallback(jobs)
+		processedJobs, err := scraper.ProcessJobsWithFallback(ctx, jobs)
+		if *newestFirst {
+			sortNewestFirst(processedJobs)
+		}
+		run := describe(err)
+		run.Filtered = filtered
 		printResultsEnhanced(processedJobs)
+		printSalaryTable(processedJobs, *salaryCurrency)
+		if historyReport != nil {
+			printHistoryReport(*historyReport)
+		}
 
 		// Save results
-		timestamp := time.Now().Format("20060102_150405")
-		filename := fmt.Sprintf("linkedin_jobs_enhanced_%s_%s_%s.json",
-			strings.ReplaceAll(strings.ToLower(country), " ", "_"),
-			strings.ReplaceAll(strings.ToLower(jobTitle), " ", "_"),
-			timestamp)
+		filename := resultsFilename(useEnhancedStrategy, country, jobTitle)
 
-		if err := SaveResults(processedJobs, filename); err != nil {
+		if err := SaveResults(processedJobs, filename, run); err != nil {
 			log.Printf("❌ Failed to save results: %v", err)
 		} else {
 			fmt.Printf("\n💾 Enhanced results saved to: %s\n", filename)
 		}
+		if run.Partial {
+			fmt.Printf("⚠️  PARTIAL RESULTS: %s (%d of %d jobs checked)\n", run.PartialReason, len(processedJobs), len(jobs))
+		}
+		printRequestStats(run)
+		printSelectorHealth(*run.Selectors)
+		printCheckDiagnosis(processedJobs)
+		var pageErr *pageclass.Error
+		if errors.As(err, &pageErr) {
+			printDiagnosis(pageErr.Result)
+		}
+		exitCode = stopStatus(run)
 
 		fmt.Println("\n✅ Enhanced job search completed!")
 		fmt.Println("📊 Review both PRIORITY jobs (with Indonesian employees) and ALTERNATIVES")
@@ -1290,24 +3197,269 @@ func main() {
 	} else {
 		fmt.Println("💡 Original Strategy: Indonesian employee focused results")
 		// Use original strategy
-		processedJobs := scraper.ProcessJobs(jobs)
+		processedJobs, err := scraper.ProcessJobs(ctx, jobs)
+		if *newestFirst {
+			sortNewestFirst(processedJobs)
+		}
+		run := describe(err)
+		run.Filtered = filtered
 		printResults(processedJobs)
+		printSalaryTable(processedJobs, *salaryCurrency)
+		if historyReport != nil {
+			printHistoryReport(*historyReport)
+		}
 
 		// Save results
-		timestamp := time.Now().Format("20060102_150405")
-		filename := fmt.Sprintf("linkedin_jobs_%s_%s_%s.json",
-			strings.ReplaceAll(strings.ToLower(country), " ", "_"),
-			strings.ReplaceAll(strings.ToLower(jobTitle), " ", "_"),
-			timestamp)
+		filename := resultsFilename(useEnhancedStrategy, country, jobTitle)
 
-		if err := SaveResults(processedJobs, filename); err != nil {
+		if err := SaveResults(processedJobs, filename, run); err != nil {
 			log.Printf("❌ Failed to save results: %v", err)
 		} else {
 			fmt.Printf("\n💾 Results saved to: %s\n", filename)
 		}
+		if run.Partial {
+			fmt.Printf("⚠️  PARTIAL RESULTS: %s (%d of %d jobs checked)\n", run.PartialReason, len(processedJobs), len(jobs))
+		}
+		printRequestStats(run)
+		printSelectorHealth(*run.Selectors)
+		printCheckDiagnosis(processedJobs)
+		var pageErr *pageclass.Error
+		if errors.As(err, &pageErr) {
+			printDiagnosis(pageErr.Result)
+		}
+		exitCode = stopStatus(run)
 
 		fmt.Println("\n✅ Job search completed!")
 	}
 
 	fmt.Println("📈 Use the JSON file for further analysis or integration with other tools.")
+	if exitCode == exitBlocked {
+		fmt.Println("\n🛑 RUN STOPPED: LinkedIn is blocking or throttling this client.")
+		fmt.Println("   Partial results were saved. Wait before running again - the scraper will not try to work around the block.")
+	}
+	return exitCode
+}
+
+// stopReason describes why the run stopped early, for user-facing messages
+func stopReason(ctx context.Context, err error, maxDuration time.Duration) string {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Sprintf("stopped after reaching --max-duration %v", maxDuration)
+	case ctx.Err() != nil:
+		return "interrupted"
+	default:
+		return err.Error()
+	}
+}
+
+// resultsFilename names the results file of a run after its search and the current time
+func resultsFilename(enhanced bool, country, jobTitle string) string {
+	prefix := "linkedin_jobs"
+	if enhanced {
+		prefix = "linkedin_jobs_enhanced"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s.json", prefix,
+		strings.ReplaceAll(strings.ToLower(country), " ", "_"),
+		strings.ReplaceAll(strings.ToLower(jobTitle), " ", "_"),
+		time.Now().Format("20060102_150405"))
+}
+
+// saveUnchecked saves the jobs of a run that stopped before its company checks, so the
+// requests already spent on searching and fetching details are not lost
+func saveUnchecked(jobs []Job, filename string, run RunInfo) {
+	run.Unchecked = true
+	if err := SaveResults(jobs, filename, run); err != nil {
+		log.Printf("❌ Failed to save results: %v", err)
+	} else {
+		fmt.Printf("\n💾 Partial results saved to: %s\n", filename)
+	}
+	fmt.Printf("⚠️  PARTIAL RESULTS: %s (%d jobs found, no companies checked)\n", run.PartialReason, len(jobs))
+	printRequestStats(run)
+}
+
+// runInfo builds the run metadata for SaveResults from the processing outcome and request stats
+func runInfo(ctx context.Context, processErr error, maxDuration time.Duration, limiter *fetcher.RateLimiter, robots *fetcher.RobotsFetcher) RunInfo {
+	stats := limiter.Stats()
+	run := RunInfo{
+		RequestsSpent:    stats.Requests,
+		RateLimitWait:    stats.Waited,
+		RobotsDisallowed: robots.Disallowed(),
+	}
+	if processErr != nil {
+		run.Partial = true
+		run.PartialReason = stopReason(ctx, processErr, maxDuration)
+		var pageErr *pageclass.Error
+		run.Blocked = errors.Is(processErr, fetcher.ErrCircuitOpen) || errors.As(processErr, &pageErr) && pageErr.Kind.Blocking()
+		run.BudgetExhausted = errors.Is(processErr, fetcher.ErrBudgetExhausted)
+	}
+	return run
+}
+
+// stopStatus is the process exit status for how a run that saved its results ended
+func stopStatus(run RunInfo) int {
+	switch {
+	case run.Blocked:
+		return exitBlocked
+	case run.BudgetExhausted:
+		return exitBudget
+	}
+	return 0
+}
+
+// loadSelectors loads the selector file over the built-in selectors. The default file is
+// optional; a file named explicitly with --selectors must exist.
+func loadSelectors(filename string, explicit bool) (*selectors.Set, error) {
+	if _, err := os.Stat(filename); os.IsNotExist(err) && !explicit {
+		return selectors.Default()
+	}
+	set, err := selectors.Load(filename)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🧩 Using selectors from %s (version %d, updated %s)", set.Source(), set.Version, set.Updated)
+	return set, nil
+}
+
+// flagPassed reports whether a flag was set explicitly on the command line
+func flagPassed(name string) bool {
+	passed := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+	return passed
+}
+
+// printRequestStats prints the requests spent, time waited for the rate limiter and URLs skipped for robots.txt
+func printRequestStats(run RunInfo) {
+	fmt.Printf("📡 Requests spent: %d | ⏳ Waiting for rate limit: %s\n", run.RequestsSpent, run.RateLimitWait.Round(time.Second))
+	fmt.Printf("📄 Search pages fetched: %d | 🔁 Duplicate jobs removed: %d\n", run.Search.PagesFetched, run.Search.DuplicatesRemoved)
+	if len(run.RobotsDisallowed) > 0 {
+		fmt.Printf("🚫 Skipped %d request(s) disallowed by robots.txt:\n", len(run.RobotsDisallowed))
+		for _, skipped := range run.RobotsDisallowed {
+			fmt.Printf("   • %s\n", skipped)
+		}
+	}
+}
+
+// printSelectorHealth prints which selectors matched and warns loudly about selector drift
+func printSelectorHealth(report selectors.Report) {
+	if len(report.Cascades) == 0 && report.StructuredPages == 0 {
+		return
+	}
+	fmt.Printf("🩺 Selector health (%s):\n", report.Source)
+	if report.StructuredPages > 0 {
+		fmt.Printf("   %d page(s) read from embedded structured data, no selectors needed\n", report.StructuredPages)
+	}
+	for _, c := range report.Cascades {
+		top := "-"
+		if len(c.Winners) > 0 {
+			top = fmt.Sprintf("%s [%s #%d]", c.Winners[0].Selector, c.Winners[0].Tier, c.Winners[0].Index)
+		}
+		fmt.Printf("   %-26s %3d lookups | primary %d · secondary %d · fallback %d · missed %d | top: %s\n",
+			c.Name, c.Lookups, c.Tiers[selectors.TierPrimary], c.Tiers[selectors.TierSecondary], c.Tiers[selectors.TierFallback], c.Misses, top)
+	}
+	if len(report.Warnings) == 0 {
+		return
+	}
+
+	fmt.Println(strings.Repeat("!", 80))
+	fmt.Println("🚨 SELECTOR DRIFT - LinkedIn's markup may have changed:")
+	for _, warning := range report.Warnings {
+		fmt.Printf("   • %s\n", warning)
+	}
+	fmt.Println("   Update the selectors in selectors.json (see selectors/default.json) - no rebuild needed.")
+	fmt.Println(strings.Repeat("!", 80))
+}
+
+// diagnosisAdvice tells the user what to do about each kind of unusable page
+var diagnosisAdvice = map[pageclass.Kind][]string{
+	pageclass.NoResults: {
+		"The search worked - LinkedIn has no jobs matching it right now",
+		"Try broader keywords, e.g. 'software engineer' instead of 'golang developer'",
+		"Try another location or fewer filters (--experience, --job-type, --workplace, --date-posted)",
+	},
+	pageclass.LoginWall: {
+		"LinkedIn wants a signed-in session for this page; the scraper only reads public pages and will not log in",
+		"Public pages usually work without one - wait 30-60 minutes and retry with a lower --rpm",
+	},
+	pageclass.AuthRedirect: {
+		"LinkedIn sent the request to its sign-in page; the scraper only reads public pages and will not log in",
+		"Public pages usually work without one - wait 30-60 minutes and retry with a lower --rpm",
+	},
+	pageclass.Challenge: {
+		"LinkedIn flagged this client and asked for a CAPTCHA; the scraper will not solve or work around it",
+		"Wait several hours before running again, then use a lower --rpm",
+	},
+	pageclass.Blocked: {
+		"LinkedIn refuses requests from this client",
+		"Wait 30-60 minutes, then retry with a lower --rpm",
+	},
+	pageclass.HTTPError: {
+		"LinkedIn returned an error page - retry later",
+		"Run with DEBUG=true to log every response",
+	},
+	pageclass.UnknownLayout: {
+		"The page has neither job cards nor a 'no results' message - LinkedIn has probably changed its markup",
+		"Update the selectors in selectors.json (see selectors/default.json and the selector health above)",
+		"Run with DEBUG=true to save the page as debug_approach_*.html",
+	},
+}
+
+// printDiagnosis explains what LinkedIn served instead of usable results, with the evidence
+func printDiagnosis(result pageclass.Result) {
+	fmt.Printf("\n🩺 DIAGNOSIS: LinkedIn served %s\n", result.Kind.Describe())
+	fmt.Printf("   Page: %s\n", result.URL)
+	for _, evidence := range result.Evidence {
+		fmt.Printf("   • %s\n", evidence)
+	}
+	for _, advice := range diagnosisAdvice[result.Kind] {
+		fmt.Printf("   → %s\n", advice)
+	}
+}
+
+// printCheckDiagnosis warns about company checks that hit a login wall or block, so
+// their jobs are not mistaken for jobs without Indonesian employees
+func printCheckDiagnosis(jobs []Job) {
+	counts := map[pageclass.Kind]int{}
+	for _, job := range jobs {
+		if job.CheckPageKind != "" {
+			counts[job.CheckPageKind]++
+		}
+	}
+	if len(counts) == 0 {
+		return
+	}
+
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+
+	fmt.Println("🚧 Some company checks could not see any employees:")
+	for _, kind := range kinds {
+		fmt.Printf("   • %d check(s) got %s\n", counts[pageclass.Kind(kind)], pageclass.Kind(kind).Describe())
+	}
+	fmt.Println("   Those jobs show no Indonesian employees because LinkedIn did not show the pages, not because there are none.")
+}
+
+// reportReplayMisses lists URLs a replayed session could not answer and reports whether there were any
+func reportReplayMisses(replayer *fetcher.Replayer) bool {
+	if replayer == nil {
+		return false
+	}
+
+	misses := replayer.Misses()
+	if len(misses) == 0 {
+		return false
+	}
+
+	fmt.Printf("\n❌ REPLAY INCOMPLETE: %d request(s) were not in the recorded session:\n", len(misses))
+	for _, miss := range misses {
+		fmt.Printf("   • %s\n", miss)
+	}
+	fmt.Println("💡 Re-record the session with --record to capture these pages.")
+	return true
 }
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goesbams/linkedin-job-scraper/fetcher"
+	"github.com/goesbams/linkedin-job-scraper/geo"
+	"github.com/goesbams/linkedin-job-scraper/pageclass"
+)
+
+// failingFetcher fails every URL containing one of its fragments and serves the rest from next
+type failingFetcher struct {
+	next  fetcher.Fetcher
+	fails []string
+}
+
+func (f failingFetcher) Fetch(ctx context.Context, url string) (*fetcher.Response, error) {
+	for _, fragment := range f.fails {
+		if strings.Contains(url, fragment) {
+			return nil, &fetcher.RetryError{URL: url, Attempts: 3, StatusCode: 503, Reason: "retries exhausted"}
+		}
+	}
+	return f.next.Fetch(ctx, url)
+}
+
+// loginWallPage is the sign-up page LinkedIn shows guests instead of more results
+const loginWallPage = `<html><head><title>Sign Up | LinkedIn</title></head>
+<body><main><h1>Join LinkedIn to see more jobs</h1><form class="join-form"></form></main></body></html>`
+
+// walledFetcher answers every search page after the first with a login wall
+type walledFetcher struct {
+	next fetcher.Fetcher
+}
+
+func (f walledFetcher) Fetch(ctx context.Context, url string) (*fetcher.Response, error) {
+	if strings.Contains(url, "/jobs/search?") && !strings.Contains(url, "start=0") {
+		return &fetcher.Response{URL: url, StatusCode: 200, Status: "200 OK", Body: []byte(loginWallPage)}, nil
+	}
+	return f.next.Fetch(ctx, url)
+}
+
+// newFixtureScraper returns a scraper that reads pages from testdata/fixtures under the
+// fixture robots.txt, failing the URLs that contain one of fails
+func newFixtureScraper(t *testing.T, fails ...string) *LinkedInScraper {
+	t.Helper()
+	files, err := fetcher.NewFileFetcher("testdata/fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	robots := fetcher.NewRobotsFetcher(failingFetcher{next: files, fails: fails}, fetcher.AgentToken(fetcher.DefaultUserAgent), nil)
+	scraper, err := NewLinkedInScraper(robots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return scraper
+}
+
+func TestSearchJobsFixtures(t *testing.T) {
+	scraper := newFixtureScraper(t)
+
+	jobs, err := scraper.SearchJobs(context.Background(), SearchQuery{Keywords: "golang developer", Location: "Germany", Limit: 4})
+	if err != nil {
+		t.Fatalf("SearchJobs: %v", err)
+	}
+
+	want := []struct {
+		id, title, company, location, jobURL, companyURL string
+		workplace                                        geo.WorkplaceType
+		salary                                           bool
+	}{
+		{"3812345678", "Senior Go Developer", "Nusantara Tech GmbH", "Berlin, Berlin, Germany",
+			"https://www.linkedin.com/jobs/view/3812345678/", "https://www.linkedin.com/company/nusantara-tech", geo.Unknown, true},
+		{"3812349999", "Backend Engineer (Go)", "Berlin Cloud AG", "Munich, Bavaria, Germany",
+			"https://www.linkedin.com/jobs/view/3812349999/", "https://www.linkedin.com/company/berlin-cloud", geo.Hybrid, false},
+	}
+	if len(jobs) != len(want) {
+		t.Fatalf("got %d jobs, want %d: %+v", len(jobs), len(want), jobs)
+	}
+	for i, w := range want {
+		job := jobs[i]
+		if job.ID != w.id || job.Title != w.title || job.Company != w.company || job.Location != w.location {
+			t.Errorf("job %d = %q %q %q %q, want %q %q %q %q", i, job.ID, job.Title, job.Company, job.Location, w.id, w.title, w.company, w.location)
+		}
+		if job.JobURL != w.jobURL || job.CompanyURL != w.companyURL {
+			t.Errorf("job %d URLs = %s %s, want %s %s", i, job.JobURL, job.CompanyURL, w.jobURL, w.companyURL)
+		}
+		if job.WorkplaceType != w.workplace {
+			t.Errorf("job %d workplace = %q, want %q", i, job.WorkplaceType, w.workplace)
+		}
+		if (job.Salary != nil) != w.salary {
+			t.Errorf("job %d salary = %+v, want salary %v", i, job.Salary, w.salary)
+		}
+		if job.PostedAt == nil {
+			t.Errorf("job %d has no posted time", i)
+		}
+	}
+
+	stats := scraper.SearchStats()
+	if stats.DuplicatesRemoved == 0 {
+		t.Errorf("the fixture answers every page alike, so later pages should only repeat jobs")
+	}
+}
+
+func TestCheckIndonesianEmployeesFailedLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		fails   []string
+		wantErr bool
+	}{
+		{"every lookup works", nil, false},
+		{"people page fails", []string{"/people/"}, true},
+		{"about page fails", []string{"/about/"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraper := newFixtureScraper(t, tt.fails...)
+			found, employees, err := scraper.CheckIndonesianEmployees(context.Background(), "https://www.linkedin.com/company/berlin-cloud")
+			if found || len(employees) > 0 {
+				t.Fatalf("found %d Indonesian employees at a company without any", len(employees))
+			}
+			var retryErr *fetcher.RetryError
+			if tt.wantErr && !errors.As(err, &retryErr) {
+				t.Errorf("err = %v, want the failed lookup's *fetcher.RetryError", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("err = %v, want a confident empty result", err)
+			}
+		})
+	}
+}
+
+func TestSearchAllSumsTotalResults(t *testing.T) {
+	scraper := newFixtureScraper(t)
+
+	queries := SearchQuery{Keywords: "golang developer", Location: "Germany"}.Expand(nil, []string{"Netherlands"})
+	if _, err := scraper.SearchAll(context.Background(), queries, 10); err != nil {
+		t.Fatalf("SearchAll: %v", err)
+	}
+
+	// Every search page of the fixtures reports 5 results
+	if got := scraper.SearchStats().TotalResults; got != 10 {
+		t.Errorf("TotalResults = %d, want 10 for two searches", got)
+	}
+}
+
+func TestSearchJobsCutOff(t *testing.T) {
+	tests := []struct {
+		limit  int
+		cutOff int
+	}{
+		{limit: 1, cutOff: 1}, // the second job of the page was left out
+		{limit: 2, cutOff: 1}, // the page reports 5 results, so more may follow
+		{limit: 4, cutOff: 0}, // page 2 only repeated jobs: LinkedIn ran out
+	}
+
+	for _, tt := range tests {
+		scraper := newFixtureScraper(t)
+		if _, err := scraper.SearchJobs(context.Background(), SearchQuery{Keywords: "golang developer", Location: "Germany", Limit: tt.limit}); err != nil {
+			t.Fatalf("SearchJobs: %v", err)
+		}
+		if got := scraper.SearchStats().CutOffSearches; got != tt.cutOff {
+			t.Errorf("limit %d: CutOffSearches = %d, want %d", tt.limit, got, tt.cutOff)
+		}
+	}
+}
+
+func TestSearchJobsLoginWallKeepsJobs(t *testing.T) {
+	files, err := fetcher.NewFileFetcher("testdata/fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	robots := fetcher.NewRobotsFetcher(walledFetcher{next: files}, fetcher.AgentToken(fetcher.DefaultUserAgent), nil)
+	scraper, err := NewLinkedInScraper(robots)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := scraper.SearchJobs(context.Background(), SearchQuery{Keywords: "golang developer", Location: "Germany", Limit: 4})
+	var pageErr *pageclass.Error
+	if !errors.As(err, &pageErr) || pageErr.Kind != pageclass.LoginWall {
+		t.Fatalf("err = %v, want a login wall *pageclass.Error", err)
+	}
+	if len(jobs) != 2 {
+		t.Errorf("got %d jobs with the error, want the 2 jobs of page 1", len(jobs))
+	}
+}
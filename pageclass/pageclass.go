@@ -0,0 +1,225 @@
+package pageclass
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Kind is what a fetched page turned out to be
+type Kind string
+
+const (
+	Content       Kind = "content"        // the page shows what was asked for
+	NoResults     Kind = "no_results"     // a search that genuinely matched nothing
+	LoginWall     Kind = "login_wall"     // the content is hidden behind a sign-in or join form
+	AuthRedirect  Kind = "auth_redirect"  // redirected to a login, signup or authwall URL
+	Challenge     Kind = "challenge"      // a CAPTCHA or security check
+	Blocked       Kind = "blocked"        // refused with status 999, 403 or 429
+	HTTPError     Kind = "http_error"     // another status than 200
+	UnknownLayout Kind = "unknown_layout" // none of the above, most likely changed markup
+)
+
+// Blocking reports whether LinkedIn refused to show the page to this client, so other
+// URLs of the same kind will not do better
+func (k Kind) Blocking() bool {
+	switch k {
+	case LoginWall, AuthRedirect, Challenge, Blocked:
+		return true
+	}
+	return false
+}
+
+// Describe names the kind in a sentence, e.g. "a login wall"
+func (k Kind) Describe() string {
+	switch k {
+	case Content:
+		return "the requested content"
+	case NoResults:
+		return "a page saying nothing matched"
+	case LoginWall:
+		return "a login wall"
+	case AuthRedirect:
+		return "a redirect to LinkedIn's sign-in"
+	case Challenge:
+		return "a security challenge (CAPTCHA)"
+	case Blocked:
+		return "a block"
+	case HTTPError:
+		return "an error page"
+	}
+	return "a page layout the selectors do not recognize"
+}
+
+// Page is a fetched page to classify
+type Page struct {
+	URL      string            // the URL requested
+	FinalURL string            // where redirects ended, "" when not redirected
+	Status   int               // HTTP status code
+	Doc      *goquery.Document // nil when the body could not be parsed
+	Items    int               // what the caller extracted: job cards, employee names
+}
+
+// Result is the kind of a page with the evidence for it
+type Result struct {
+	Kind     Kind     `json:"kind"`
+	URL      string   `json:"url"`
+	Evidence []string `json:"evidence"`
+}
+
+// Error is returned for a page that cannot be used
+type Error struct {
+	Result
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("LinkedIn served %s for %s (%s)", e.Kind.Describe(), e.URL, strings.Join(e.Evidence, "; "))
+}
+
+var (
+	// authPaths are where LinkedIn sends visitors it wants signed in
+	authPaths = regexp.MustCompile(`^/(?:authwall|login|uas/login|signup|reg/join|join)(?:/|$)`)
+
+	// challengePaths are LinkedIn's security check pages
+	challengePaths = regexp.MustCompile(`^/checkpoint/challenge`)
+
+	// challengeSelectors match CAPTCHA widgets and challenge forms
+	challengeSelectors = []string{
+		"#captcha-internal", "iframe[src*='captcha']", "iframe[src*='arkoselabs']", ".g-recaptcha", "[data-sitekey]",
+		"form[action*='checkpoint/challenge']", "#challenge-form",
+	}
+	challengeText = regexp.MustCompile(`security verification|security check|verify you are human|verify you're human|are you a robot|unusual activity from your`)
+
+	// noResultsSelectors and noResultsText match LinkedIn's "no matching jobs" page in English, German, Dutch and Indonesian
+	noResultsSelectors = []string{".jobs-search-no-results", ".jobs-search-no-results-banner", ".jobs-search-two-pane__no-results-banner", ".no-results"}
+	noResultsText      = regexp.MustCompile(`no matching jobs found|we couldn[’']t find a match|no results found|keine passenden (?:jobs|stellen)|keine treffer|geen overeenkomende vacatures|geen resultaten|tidak ada lowongan yang cocok|tidak ada hasil`)
+
+	// loginSelectors match sign-in and join forms outside of LinkedIn's sign-in modals
+	loginSelectors = []string{
+		"form.login__form", "form[action*='login-submit']", "input[name='session_key']", ".authwall-join-form", "form.join-form",
+	}
+	loginText  = regexp.MustCompile(`sign in to (?:view|see|continue)|join (?:linkedin|now) to (?:view|see)|agree & join linkedin|melden sie sich an, um|log in om|masuk untuk melihat`)
+	loginTitle = regexp.MustCompile(`(?i)\b(?:log ?in|sign in|sign up|join linkedin|anmelden|registrieren|inloggen|masuk)\b`)
+
+	// landmarks are reported for pages of unknown layout, to help updating the selectors
+	landmarks = []string{"main", "[data-entity-urn]", "[data-job-id]", ".base-card", "li", "article", "form"}
+)
+
+// Classify decides what a fetched page is. Redirects and challenges are checked first,
+// then the status code; a 200 page with items is content, otherwise it is told apart as a
+// genuine empty result, a login wall or an unknown layout. Sign-in modals, which LinkedIn
+// adds to every public page, are not taken for a login wall.
+func Classify(p Page) Result {
+	result := Result{URL: p.URL}
+	evidence := func(kind Kind, format string, args ...interface{}) Result {
+		result.Kind = kind
+		result.Evidence = append(result.Evidence, fmt.Sprintf(format, args...))
+		return result
+	}
+
+	if p.FinalURL != "" {
+		if u, err := url.Parse(p.FinalURL); err == nil {
+			switch {
+			case challengePaths.MatchString(u.Path):
+				return evidence(Challenge, "redirected to %s", p.FinalURL)
+			case authPaths.MatchString(u.Path):
+				return evidence(AuthRedirect, "redirected to %s", p.FinalURL)
+			}
+		}
+	}
+
+	var text string
+	if p.Doc != nil {
+		text = visibleText(p.Doc)
+		for _, selector := range challengeSelectors {
+			if p.Doc.Find(selector).Length() > 0 {
+				evidence(Challenge, "element %q", selector)
+			}
+		}
+		if match := challengeText.FindString(text); match != "" {
+			evidence(Challenge, "text %q", match)
+		}
+		if result.Kind == Challenge {
+			return result
+		}
+	}
+
+	switch {
+	case p.Status == 999:
+		return evidence(Blocked, "status 999 (LinkedIn's bot block)")
+	case p.Status == 429:
+		return evidence(Blocked, "status 429 (rate limited)")
+	case p.Status == 403:
+		return evidence(Blocked, "status 403 (forbidden)")
+	case p.Status != 200:
+		return evidence(HTTPError, "status %d", p.Status)
+	case p.Items > 0:
+		return evidence(Content, "%d items", p.Items)
+	case p.Doc == nil:
+		return evidence(UnknownLayout, "page could not be parsed")
+	}
+
+	for _, selector := range noResultsSelectors {
+		if p.Doc.Find(selector).Length() > 0 {
+			evidence(NoResults, "element %q", selector)
+		}
+	}
+	if match := noResultsText.FindString(text); match != "" {
+		evidence(NoResults, "text %q", match)
+	}
+	if result.Kind == NoResults {
+		return result
+	}
+
+	for _, selector := range loginSelectors {
+		if outsideModals(p.Doc.Find(selector)).Length() > 0 {
+			evidence(LoginWall, "element %q", selector)
+		}
+	}
+	if match := loginText.FindString(text); match != "" {
+		evidence(LoginWall, "text %q", match)
+	}
+	title := strings.TrimSpace(p.Doc.Find("title").First().Text())
+	if result.Kind == LoginWall {
+		if loginTitle.MatchString(title) {
+			evidence(LoginWall, "title %q", title)
+		}
+		return result
+	}
+
+	evidence(UnknownLayout, "no items, no 'no results' message and no login form")
+	evidence(UnknownLayout, "title %q", title)
+	var found []string
+	for _, selector := range landmarks {
+		if n := p.Doc.Find(selector).Length(); n > 0 {
+			found = append(found, fmt.Sprintf("%s×%d", selector, n))
+		}
+	}
+	if len(found) == 0 {
+		found = append(found, "none")
+	}
+	evidence(UnknownLayout, "%d elements; landmarks: %s", p.Doc.Find("*").Length(), strings.Join(found, ", "))
+	return result
+}
+
+// visibleText returns the lower-cased text of the page body without scripts, embedded
+// data and sign-in modals
+func visibleText(doc *goquery.Document) string {
+	body := doc.Find("body").Clone()
+	body.Find("script, style, noscript, template, code").Remove()
+	body.Find(modalSelector).Remove()
+	return strings.ToLower(strings.Join(strings.Fields(body.Text()), " "))
+}
+
+// modalSelector matches the dialogs LinkedIn renders on public pages
+const modalSelector = "[class*='modal'], [role='dialog'], dialog"
+
+// outsideModals keeps the elements of sel that are not part of a dialog
+func outsideModals(sel *goquery.Selection) *goquery.Selection {
+	return sel.FilterFunction(func(_ int, el *goquery.Selection) bool {
+		return el.Closest(modalSelector).Length() == 0
+	})
+}
@@ -0,0 +1,77 @@
+package pageclass
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func parse(t *testing.T, page string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestClassify(t *testing.T) {
+	const search = "https://www.linkedin.com/jobs/search?keywords=golang&location=Germany"
+
+	tests := []struct {
+		name     string
+		finalURL string
+		status   int
+		page     string
+		items    int
+		want     Kind
+	}{
+		{"job cards", "", 200, `<ul><li class="base-card">Senior Go Developer</li></ul>`, 1, Content},
+		{"cards next to a sign-in modal", "", 200,
+			`<div class="contextual-sign-in-modal"><form class="login__form"><input name="session_key"></form></div><ul><li>Go</li></ul>`, 1, Content},
+		{"no matching jobs", "", 200, `<main><h1>No matching jobs found.</h1></main>`, 0, NoResults},
+		{"no results banner", "", 200, `<div class="jobs-search-no-results-banner"></div>`, 0, NoResults},
+		{"German no results", "", 200, `<p>Keine passenden Jobs gefunden</p>`, 0, NoResults},
+		{"authwall redirect", "https://www.linkedin.com/authwall?trk=gf&sessionRedirect=x", 200, `<html></html>`, 0, AuthRedirect},
+		{"login redirect", "https://www.linkedin.com/uas/login?session_redirect=x", 200, `<html></html>`, 0, AuthRedirect},
+		{"challenge redirect", "https://www.linkedin.com/checkpoint/challenge/AgF", 200, `<html></html>`, 0, Challenge},
+		{"captcha widget", "", 200, `<iframe src="https://www.linkedin.com/captcha/v2"></iframe>`, 0, Challenge},
+		{"captcha on a 999", "", 999, `<p>Please complete this security check to access LinkedIn</p>`, 0, Challenge},
+		{"status 999", "", 999, `<html></html>`, 0, Blocked},
+		{"status 429", "", 429, ``, 0, Blocked},
+		{"status 403", "", 403, ``, 0, Blocked},
+		{"status 500", "", 500, `<h1>Something went wrong</h1>`, 0, HTTPError},
+		{"join form", "", 200,
+			`<title>Sign Up | LinkedIn</title><form class="join-form"><input name="email"></form>`, 0, LoginWall},
+		{"sign in text", "", 200, `<main><p>Sign in to view more jobs</p></main>`, 0, LoginWall},
+		{"only a sign-in modal", "", 200,
+			`<title>Golang jobs</title><div role="dialog"><p>Sign in to view more jobs</p><form class="login__form"></form></div><main></main>`, 0, UnknownLayout},
+		{"changed markup", "", 200, `<title>Golang jobs</title><main><div class="jobs-v3-card">Go</div></main>`, 0, UnknownLayout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc *goquery.Document
+			if tt.page != "" {
+				doc = parse(t, tt.page)
+			}
+			got := Classify(Page{URL: search, FinalURL: tt.finalURL, Status: tt.status, Doc: doc, Items: tt.items})
+			if got.Kind != tt.want {
+				t.Errorf("Classify = %s (%s), want %s", got.Kind, strings.Join(got.Evidence, "; "), tt.want)
+			}
+			if got.URL != search || len(got.Evidence) == 0 {
+				t.Errorf("Classify = %+v, want the URL and evidence", got)
+			}
+		})
+	}
+}
+
+func TestBlocking(t *testing.T) {
+	for _, kind := range []Kind{Content, NoResults, LoginWall, AuthRedirect, Challenge, Blocked, HTTPError, UnknownLayout} {
+		want := kind == LoginWall || kind == AuthRedirect || kind == Challenge || kind == Blocked
+		if got := kind.Blocking(); got != want {
+			t.Errorf("%s.Blocking() = %v, want %v", kind, got, want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package posted
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Precision tells how exactly a posted time is known
+type Precision string
+
+const (
+	Exact  Precision = "exact" // a full timestamp from the page
+	Minute Precision = "minute"
+	Hour   Precision = "hour"
+	Day    Precision = "day"
+	Week   Precision = "week"
+	Month  Precision = "month"
+	Year   Precision = "year"
+)
+
+// unit is a time unit named in a relative date
+type unit struct {
+	days, months, years int           // calendar step for days, weeks, months and years
+	duration            time.Duration // step for seconds, minutes and hours
+	precision           Precision
+}
+
+var (
+	second = unit{duration: time.Second, precision: Minute}
+	minute = unit{duration: time.Minute, precision: Minute}
+	hour   = unit{duration: time.Hour, precision: Hour}
+	day    = unit{days: 1, precision: Day}
+	week   = unit{days: 7, precision: Week}
+	month  = unit{months: 1, precision: Month}
+	year   = unit{years: 1, precision: Year}
+)
+
+// units maps English, German, Dutch and Indonesian unit words (and LinkedIn's short forms) to units
+var units = map[string]unit{
+	// English
+	"second": second, "seconds": second, "minute": minute, "minutes": minute, "min": minute, "mins": minute,
+	"hour": hour, "hours": hour, "hr": hour, "hrs": hour, "day": day, "days": day, "week": week, "weeks": week,
+	"month": month, "months": month, "year": year, "years": year,
+	"s": second, "m": minute, "h": hour, "d": day, "w": week, "mo": month, "y": year, "yr": year, "yrs": year,
+	// German
+	"sekunde": second, "sekunden": second, "minuten": minute, "stunde": hour, "stunden": hour,
+	"tag": day, "tagen": day, "woche": week, "wochen": week, "monat": month, "monaten": month,
+	"jahr": year, "jahren": year,
+	// Dutch
+	"seconde": second, "seconden": second, "minuut": minute, "uur": hour, "dag": day, "dagen": day,
+	"weken": week, "maand": month, "maanden": month, "jaar": year,
+	// Indonesian
+	"detik": second, "menit": minute, "jam": hour, "hari": day, "minggu": week, "bulan": month, "tahun": year,
+}
+
+// counts are number words meaning one
+var counts = map[string]int{
+	"a": 1, "an": 1, "one": 1,
+	"ein": 1, "einer": 1, "einem": 1, "eine": 1,
+	"een": 1, "één": 1,
+	"satu": 1, "se": 1,
+}
+
+var (
+	// relativePatterns capture a count and a unit: "2 days ago", "vor 3 Tagen", "3 dagen geleden", "2 hari yang lalu"
+	relativePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\b(\d+|an?|one)\+?\s*([a-z]+)\s+ago\b`),
+		regexp.MustCompile(`\bvor\s+(\d+|einer|einem|eine|ein)\s+([a-zäöü]+)`),
+		regexp.MustCompile(`(?:^|\s)(\d+|een|één)\s+([a-z]+)\s+geleden\b`),
+		regexp.MustCompile(`\b(\d+|satu|se)\s*([a-z]+)\s+(?:yang\s+)?lalu\b`),
+	}
+
+	// sameDay and previousDay are words for today and yesterday in the same languages
+	sameDay     = regexp.MustCompile(`\b(?:just now|today|moments ago|gerade eben|heute|soeben|zojuist|vandaag|baru saja|hari ini)\b`)
+	previousDay = regexp.MustCompile(`\b(?:yesterday|gestern|gisteren|kemarin)\b`)
+
+	// agePattern reads --max-age values such as 12h, 7d or 2w
+	agePattern = regexp.MustCompile(`^(\d+)\s*([hdw])$`)
+)
+
+// Parse reads a posted time from page text ("2 days ago", "vor 3 Tagen", "Reposted 1 week ago")
+// or a datetime attribute ("2025-01-10"). Relative times count back from fetchedAt.
+func Parse(text string, fetchedAt time.Time) (time.Time, Precision, bool) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return time.Time{}, "", false
+	}
+
+	if at, err := time.Parse(time.RFC3339, text); err == nil {
+		return at, Exact, true
+	}
+	if at, err := time.ParseInLocation("2006-01-02", text, fetchedAt.Location()); err == nil {
+		return at, Day, true
+	}
+	text = strings.ToLower(text)
+
+	for _, pattern := range relativePatterns {
+		match := pattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		u, ok := units[match[2]]
+		if !ok {
+			continue
+		}
+		n, ok := counts[match[1]]
+		if !ok {
+			n, _ = strconv.Atoi(match[1])
+		}
+		at := fetchedAt.Add(-time.Duration(n)*u.duration).AddDate(-n*u.years, -n*u.months, -n*u.days)
+		if strings.Contains(match[0], "+") && u.precision.Finer(Month) {
+			return at, Month, true // "30+ days ago" is only a lower bound
+		}
+		return at, u.precision, true
+	}
+
+	switch {
+	case sameDay.MatchString(text):
+		return fetchedAt, Day, true
+	case previousDay.MatchString(text):
+		return fetchedAt.AddDate(0, 0, -1), Day, true
+	}
+
+	return time.Time{}, "", false
+}
+
+// Finer reports whether p is more exact than other; an unknown precision is the coarsest
+func (p Precision) Finer(other Precision) bool {
+	return rank(p) < rank(other)
+}
+
+// rank orders precisions from exact to unknown
+func rank(p Precision) int {
+	for i, known := range []Precision{Exact, Minute, Hour, Day, Week, Month, Year} {
+		if p == known {
+			return i
+		}
+	}
+	return 99
+}
+
+// ParseAge reads a maximum age such as "12h", "7d" or "2w" (or a Go duration like "36h30m")
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if match := agePattern.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "h":
+			return time.Duration(n) * time.Hour, nil
+		case "d":
+			return time.Duration(n) * 24 * time.Hour, nil
+		case "w":
+			return time.Duration(n) * 7 * 24 * time.Hour, nil
+		}
+	}
+	if age, err := time.ParseDuration(value); err == nil && age > 0 {
+		return age, nil
+	}
+	return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d or 2w)", value)
+}
+
+// Format shows a posted time only as exactly as it is known
+func Format(at time.Time, precision Precision) string {
+	switch precision {
+	case Exact, Minute, Hour:
+		return at.Format("2006-01-02 15:04")
+	case Day:
+		return at.Format("2006-01-02")
+	case Week, Month:
+		return "~" + at.Format("2006-01-02")
+	case Year:
+		return "~" + at.Format("2006-01")
+	}
+	return at.Format("2006-01-02")
+}
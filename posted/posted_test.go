@@ -0,0 +1,86 @@
+package posted
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	fetchedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text      string
+		want      time.Time
+		precision Precision
+		ok        bool
+	}{
+		{"2 days ago", time.Date(2025, 1, 13, 12, 0, 0, 0, time.UTC), Day, true},
+		{"Reposted 1 week ago", time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC), Week, true},
+		{"an hour ago", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC), Hour, true},
+		{"5 minutes ago", time.Date(2025, 1, 15, 11, 55, 0, 0, time.UTC), Minute, true},
+		{"3 months ago", time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC), Month, true},
+		{"30+ days ago", time.Date(2024, 12, 16, 12, 0, 0, 0, time.UTC), Month, true},
+		{"2w ago", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), Week, true},
+		{"vor 3 Tagen", time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC), Day, true},
+		{"vor einer Woche", time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC), Week, true},
+		{"3 dagen geleden", time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC), Day, true},
+		{"2 hari yang lalu", time.Date(2025, 1, 13, 12, 0, 0, 0, time.UTC), Day, true},
+		{"Just now", fetchedAt, Day, true},
+		{"gestern", time.Date(2025, 1, 14, 12, 0, 0, 0, time.UTC), Day, true},
+		{"2025-01-10", time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), Day, true},
+		{"2025-01-10T08:30:00Z", time.Date(2025, 1, 10, 8, 30, 0, 0, time.UTC), Exact, true},
+		{"", time.Time{}, "", false},
+		{"Be an early applicant", time.Time{}, "", false},
+		{"2 fortnights ago", time.Time{}, "", false},
+	}
+
+	for _, tt := range tests {
+		got, precision, ok := Parse(tt.text, fetchedAt)
+		if ok != tt.ok || !got.Equal(tt.want) || precision != tt.precision {
+			t.Errorf("Parse(%q) = %v, %q, %v, want %v, %q, %v", tt.text, got, precision, ok, tt.want, tt.precision, tt.ok)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"12h", 12 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"2W", 14 * 24 * time.Hour, false},
+		{"36h30m", 36*time.Hour + 30*time.Minute, false},
+		{"a week", 0, true},
+		{"-1h", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, %v, want %v (error %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	at := time.Date(2025, 1, 10, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		precision Precision
+		want      string
+	}{
+		{Exact, "2025-01-10 08:30"},
+		{Hour, "2025-01-10 08:30"},
+		{Day, "2025-01-10"},
+		{Week, "~2025-01-10"},
+		{Year, "~2025-01"},
+	}
+
+	for _, tt := range tests {
+		if got := Format(at, tt.precision); got != tt.want {
+			t.Errorf("Format(%v, %q) = %q, want %q", at, tt.precision, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package salary
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rates converts amounts between currencies with a user-maintained table (see data/exchange_rates.txt)
+type Rates struct {
+	perEUR  map[string]float64 // units of each currency per 1 EUR
+	Updated string             // from the "# updated:" line, "" when missing
+}
+
+// LoadRates reads an exchange rate file
+func LoadRates(filename string) (*Rates, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := &Rates{perEUR: map[string]float64{"EUR": 1}}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "# updated:") {
+			r.Updated = strings.TrimSpace(strings.TrimPrefix(line, "# updated:"))
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "|")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected \"code | rate\", got %q", filename, lineNumber, line)
+		}
+		code := strings.ToUpper(strings.TrimSpace(fields[0]))
+		rate, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("%s:%d: invalid rate for %s: %q", filename, lineNumber, code, strings.TrimSpace(fields[1]))
+		}
+		r.perEUR[code] = rate
+	}
+
+	return r, scanner.Err()
+}
+
+// Currencies lists the currency codes the table knows
+func (r *Rates) Currencies() []string {
+	codes := make([]string, 0, len(r.perEUR))
+	for code := range r.perEUR {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Knows reports whether the table has a rate for the currency
+func (r *Rates) Knows(currency string) bool {
+	_, ok := r.perEUR[strings.ToUpper(currency)]
+	return ok
+}
+
+// Convert converts an amount between two currencies in the table
+func (r *Rates) Convert(amount float64, from, to string) (float64, bool) {
+	fromRate, ok := r.perEUR[strings.ToUpper(from)]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r.perEUR[strings.ToUpper(to)]
+	if !ok {
+		return 0, false
+	}
+	return amount / fromRate * toRate, true
+}
+
+// Normalize fills s.Normalized with the annual range in currency. It reports false, leaving
+// Normalized empty, when the table has no rate for the salary's currency.
+func (r *Rates) Normalize(s *Salary, currency string) bool {
+	low, ok := r.Convert(s.Annual.Min, s.Currency, currency)
+	if !ok {
+		return false
+	}
+	high, _ := r.Convert(s.Annual.Max, s.Currency, currency)
+	s.Normalized = &Amount{Min: low, Max: high, Currency: strings.ToUpper(currency)}
+	return true
+}
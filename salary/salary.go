@@ -0,0 +1,287 @@
+package salary
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Period is the time span a pay figure is given for
+type Period string
+
+const (
+	Hourly  Period = "hour"
+	Daily   Period = "day"
+	Weekly  Period = "week"
+	Monthly Period = "month"
+	Yearly  Period = "year"
+)
+
+// periodsPerYear turns a pay figure into an annual one (40-hour weeks, 260 working days)
+var periodsPerYear = map[Period]float64{
+	Hourly: 2080, Daily: 260, Weekly: 52, Monthly: 12, Yearly: 1,
+}
+
+// Amount is an annual pay range in one currency
+type Amount struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Currency string  `json:"currency"`
+}
+
+// Salary is a pay range as the posting states it, plus its annual equivalent
+type Salary struct {
+	Min           float64 `json:"min"`
+	Max           float64 `json:"max"`
+	Currency      string  `json:"currency"` // ISO 4217 code
+	Period        Period  `json:"period"`
+	PeriodGuessed bool    `json:"period_guessed,omitempty"` // the posting did not say; guessed from the amount
+	Raw           string  `json:"raw"`
+	Annual        Amount  `json:"annual"`               // in Currency
+	Normalized    *Amount `json:"normalized,omitempty"` // in the report currency, see Rates.Normalize
+}
+
+// currencySymbols maps symbols and codes as they appear in postings to ISO codes
+var currencySymbols = map[string]string{
+	"€": "EUR", "eur": "EUR", "euro": "EUR", "euros": "EUR",
+	"$": "USD", "us$": "USD", "usd": "USD",
+	"£": "GBP", "gbp": "GBP",
+	"rp": "IDR", "rp.": "IDR", "idr": "IDR",
+	"chf": "CHF", "sgd": "SGD", "s$": "SGD",
+}
+
+// multipliers are the shorthand suffixes for thousands and millions, including German,
+// Dutch and Indonesian ones ("60K", "10 juta", "500 rb")
+var multipliers = map[string]float64{
+	"k": 1e3, "tsd": 1e3, "tsd.": 1e3, "rb": 1e3, "ribu": 1e3,
+	"m": 1e6, "mio": 1e6, "mio.": 1e6, "juta": 1e6, "jt": 1e6,
+}
+
+const (
+	currencyPattern = `(\b(?:us\$|s\$|rp\.?|(?:euros?|eur|usd|gbp|idr|chf|sgd)\b)|€|\$|£)`
+	amountPattern   = `(\d{1,3}(?:[.,' ]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?)\s*(k|tsd\.?|rb|ribu|mio\.?|m|juta|jt)?\b`
+)
+
+var (
+	// salaryPattern matches one amount or a range, with the currency before or after either end
+	salaryPattern = regexp.MustCompile(`(?:` + currencyPattern + `\s*)?` + amountPattern + `(?:\s*` + currencyPattern + `)?` +
+		`(?:(?:\s*/\s*[a-z]+)?\s*(?:-|–|—|to|bis|tot|sd|s/d|s\.d\.|hingga|until)\s*(?:` + currencyPattern + `\s*)?` + amountPattern + `(?:\s*` + currencyPattern + `)?)?`)
+
+	// periodPatterns recognize pay periods in English, German, Dutch and Indonesian
+	periodPatterns = []struct {
+		period  Period
+		pattern *regexp.Regexp
+	}{
+		{Hourly, regexp.MustCompile(`/\s*(?:hr|hour|h|std|stunde|uur|jam)\b|\b(?:per|an|pro)\s+(?:hour|stunde|uur|jam)\b|\bhourly\b|\bstündlich\b|\bstundenlohn\b`)},
+		{Daily, regexp.MustCompile(`/\s*(?:day|tag|dag|hari)\b|\b(?:per|a|pro)\s+(?:day|tag|dag|hari)\b|\bdaily\b|\btäglich\b|\btagessatz\b`)},
+		{Weekly, regexp.MustCompile(`/\s*(?:wk|week|woche)\b|\b(?:per|a|pro)\s+(?:week|woche|minggu)\b|\bweekly\b|\bwöchentlich\b`)},
+		{Monthly, regexp.MustCompile(`/\s*(?:mo|month|monat|mnd|maand|bulan|bln)\b|\b(?:per|a|pro|im)\s+(?:month|monat|maand|bulan)\b|\bmonthly\b|\bmonatlich\b|\bmaandelijks\b|\bsebulan\b`)},
+		{Yearly, regexp.MustCompile(`/\s*(?:yr|year|jahr|jaar|tahun|thn|a)\b|\b(?:per|a|pro|im)\s+(?:year|annum|jahr|jaar|tahun)\b|\b(?:annual|annually|yearly|jährlich|jahresgehalt|jaarlijks|jaarsalaris|p\.\s?a\.|pa)\b`)},
+	}
+
+	// salaryWords show that a description line talks about pay
+	salaryWords = regexp.MustCompile(`\b(?:salary|salaries|pay|compensation|remuneration|wage|gehalt|vergütung|jahresgehalt|lohn|salaris|beloning|gaji|upah)\b`)
+)
+
+// Parse reads a pay figure such as "€60K - €75K/yr", "45.000 € – 55.000 € brutto" or "$50/hr".
+// A currency is required; when the period is missing it is guessed from the amount.
+func Parse(text string) (Salary, bool) {
+	raw := strings.Join(strings.Fields(strings.NewReplacer(" ", " ", " ", " ").Replace(text)), " ")
+	lower := strings.ToLower(raw)
+
+	for _, match := range salaryPattern.FindAllStringSubmatchIndex(lower, -1) {
+		group := func(n int) string {
+			if match[2*n] < 0 {
+				return ""
+			}
+			return lower[match[2*n]:match[2*n+1]]
+		}
+
+		currency := ""
+		for _, n := range []int{1, 4, 5, 8} {
+			if code := currencySymbols[group(n)]; code != "" {
+				currency = code
+				break
+			}
+		}
+		if currency == "" {
+			continue
+		}
+
+		low, ok := parseNumber(group(2))
+		if !ok {
+			continue
+		}
+		high, lowUnit, highUnit := low, group(3), group(3)
+		if group(6) != "" {
+			if high, ok = parseNumber(group(6)); !ok {
+				continue
+			}
+			highUnit = group(7)
+			if lowUnit == "" {
+				lowUnit = highUnit // "€60 - 75K"
+			}
+		}
+		low *= unitFactor(lowUnit)
+		high *= unitFactor(highUnit)
+		if low <= 0 || high < low {
+			continue
+		}
+
+		s := Salary{Min: low, Max: high, Currency: currency, Raw: strings.TrimSpace(raw[match[0]:match[1]])}
+		s.Period, s.PeriodGuessed = findPeriod(lower[match[1]:], lower[:match[0]], low, currency)
+		s.Annual = Amount{Min: s.Min * periodsPerYear[s.Period], Max: s.Max * periodsPerYear[s.Period], Currency: currency}
+		return s, true
+	}
+
+	return Salary{}, false
+}
+
+// New builds a salary from figures given as data rather than text, such as a schema.org
+// MonetaryAmount. Either end may be zero for an open range; an empty period is guessed from the amount.
+func New(min, max float64, currency string, period Period) (Salary, bool) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if max == 0 {
+		max = min
+	}
+	if min == 0 {
+		min = max
+	}
+	if currency == "" || min <= 0 || max < min {
+		return Salary{}, false
+	}
+
+	s := Salary{Min: min, Max: max, Currency: currency, Period: period}
+	if _, ok := periodsPerYear[period]; !ok {
+		s.Period, s.PeriodGuessed = findPeriod("", "", min, currency)
+	}
+	s.Annual = Amount{Min: s.Min * periodsPerYear[s.Period], Max: s.Max * periodsPerYear[s.Period], Currency: currency}
+	s.Raw = s.String()
+	return s, true
+}
+
+// Find looks for pay in a job description, only trusting lines that talk about pay
+// or name a pay period, so funding rounds and prices are not taken for salaries
+func Find(description string) (Salary, bool) {
+	for _, line := range strings.Split(description, "\n") {
+		lower := strings.ToLower(line)
+		hasPeriod := false
+		for _, p := range periodPatterns {
+			if p.pattern.MatchString(lower) {
+				hasPeriod = true
+				break
+			}
+		}
+		if !salaryWords.MatchString(lower) && !hasPeriod {
+			continue
+		}
+		if s, ok := Parse(line); ok {
+			s.Raw = strings.TrimSpace(line)
+			if len(s.Raw) > 200 {
+				s.Raw = s.Raw[:200] + "..."
+			}
+			return s, true
+		}
+	}
+	return Salary{}, false
+}
+
+// parseNumber reads "45.000", "45,000", "60,5", "1.234,56" or "45 000". A last separator
+// followed by one or two digits is a decimal point; all other separators group thousands.
+func parseNumber(value string) (float64, bool) {
+	value = strings.NewReplacer(" ", "", "'", "").Replace(value)
+	decimal := ""
+	if i := strings.LastIndexAny(value, ".,"); i >= 0 && len(value)-i-1 <= 2 {
+		value, decimal = value[:i], value[i+1:]
+	}
+	value = strings.NewReplacer(".", "", ",", "").Replace(value)
+	if decimal != "" {
+		value += "." + decimal
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	return n, err == nil
+}
+
+// unitFactor returns the multiplier for a "K", "Mio" or "juta" suffix
+func unitFactor(suffix string) float64 {
+	if factor, ok := multipliers[suffix]; ok {
+		return factor
+	}
+	return 1
+}
+
+// findPeriod finds the pay period closest after a salary, then closest before it, or guesses it
+// from the amount: Indonesian pay is usually quoted per month, elsewhere small amounts are hourly rates
+func findPeriod(after, before string, amount float64, currency string) (Period, bool) {
+	var found Period
+	closest := -1
+	for _, p := range periodPatterns {
+		if at := p.pattern.FindStringIndex(after); at != nil && (closest < 0 || at[0] < closest) {
+			found, closest = p.period, at[0]
+		}
+	}
+	if found != "" {
+		return found, false
+	}
+	for _, p := range periodPatterns {
+		all := p.pattern.FindAllStringIndex(before, -1)
+		if len(all) > 0 && all[len(all)-1][0] > closest {
+			found, closest = p.period, all[len(all)-1][0]
+		}
+	}
+	if found != "" {
+		return found, false
+	}
+
+	switch {
+	case currency == "IDR" && amount >= 100e6:
+		return Yearly, true
+	case currency == "IDR":
+		return Monthly, true
+	case amount >= 15000:
+		return Yearly, true
+	case amount >= 1000:
+		return Monthly, true
+	case amount >= 200:
+		return Daily, true
+	}
+	return Hourly, true
+}
+
+// String formats the range as the posting gives it, e.g. "€60,000 – €75,000 / year"
+func (s Salary) String() string {
+	text := FormatAmount(s.Min, s.Currency)
+	if s.Max != s.Min {
+		text += " – " + FormatAmount(s.Max, s.Currency)
+	}
+	return text + " / " + string(s.Period)
+}
+
+// currencyPrefixes are written before the amount; other codes follow it
+var currencyPrefixes = map[string]string{"EUR": "€", "USD": "$", "GBP": "£", "IDR": "Rp "}
+
+// FormatAmount writes an amount with thousands separators and its currency
+func FormatAmount(amount float64, currency string) string {
+	whole := strconv.FormatInt(int64(math.Round(amount)), 10)
+	if amount < 1000 && amount != math.Trunc(amount) {
+		whole = strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+
+	var grouped []string
+	intPart, fraction := whole, ""
+	if i := strings.IndexByte(whole, '.'); i >= 0 {
+		intPart, fraction = whole[:i], whole[i:]
+	}
+	for len(intPart) > 3 {
+		grouped = append([]string{intPart[len(intPart)-3:]}, grouped...)
+		intPart = intPart[:len(intPart)-3]
+	}
+	grouped = append([]string{intPart}, grouped...)
+	number := strings.Join(grouped, ",") + fraction
+
+	if prefix, ok := currencyPrefixes[currency]; ok {
+		return prefix + number
+	}
+	return number + " " + currency
+}
@@ -0,0 +1,126 @@
+package salary
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text     string
+		min, max float64
+		currency string
+		period   Period
+		guessed  bool
+	}{
+		{"€60K - €75K/yr", 60000, 75000, "EUR", Yearly, false},
+		{"€60 - 75K per year", 60000, 75000, "EUR", Yearly, false},
+		{"45.000 € – 55.000 € brutto im Jahr", 45000, 55000, "EUR", Yearly, false},
+		{"$50/hr", 50, 50, "USD", Hourly, false},
+		{"$120,000 to $150,000", 120000, 150000, "USD", Yearly, true},
+		{"€4.500 pro Monat", 4500, 4500, "EUR", Monthly, false},
+		{"€ 3.200,50 monatlich", 3200.50, 3200.50, "EUR", Monthly, false},
+		{"Rp 15 juta - 25 juta", 15e6, 25e6, "IDR", Monthly, true},
+		{"Rp 15.000.000 per bulan", 15e6, 15e6, "IDR", Monthly, false},
+		{"CHF 110 000 - 130 000", 110000, 130000, "CHF", Yearly, true},
+		{"£450 a day", 450, 450, "GBP", Daily, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.text)
+		if !ok {
+			t.Errorf("Parse(%q) found no salary", tt.text)
+			continue
+		}
+		if got.Min != tt.min || got.Max != tt.max || got.Currency != tt.currency || got.Period != tt.period || got.PeriodGuessed != tt.guessed {
+			t.Errorf("Parse(%q) = %v-%v %s /%s (guessed %v), want %v-%v %s /%s (guessed %v)",
+				tt.text, got.Min, got.Max, got.Currency, got.Period, got.PeriodGuessed, tt.min, tt.max, tt.currency, tt.period, tt.guessed)
+		}
+		if want := tt.min * periodsPerYear[tt.period]; got.Annual.Min != want {
+			t.Errorf("Parse(%q).Annual.Min = %v, want %v", tt.text, got.Annual.Min, want)
+		}
+	}
+}
+
+func TestParseNoSalary(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"5+ years of Go experience",
+		"60K - 75K per year", // no currency
+		"Team of 25 engineers in 3 offices",
+	} {
+		if got, ok := Parse(text); ok {
+			t.Errorf("Parse(%q) = %+v, want no salary", text, got)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		description string
+		min         float64
+		ok          bool
+	}{
+		{"We build payment APIs in Go.\nSalary: €65,000 - €80,000 plus bonus.", 65000, true},
+		{"Gehalt: 70.000 € brutto jährlich", 70000, true},
+		{"We raised $20M in our Series B.\nWork from our Berlin office.", 0, false},
+		{"Lunch costs €5 at the canteen.", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Find(tt.description)
+		if ok != tt.ok || got.Min != tt.min {
+			t.Errorf("Find(%q) = %v, %v, want min %v, %v", tt.description, got.Min, ok, tt.min, tt.ok)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     string
+	}{
+		{60000, "EUR", "€60,000"},
+		{1234567, "USD", "$1,234,567"},
+		{15e6, "IDR", "Rp 15,000,000"},
+		{52.5, "GBP", "£52.50"},
+		{110000, "CHF", "110,000 CHF"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAmount(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("FormatAmount(%v, %s) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.txt")
+	table := "# updated: 2025-01-01\nEUR | 1\nUSD | 1.25\nIDR | 17500\n"
+	if err := os.WriteFile(path, []byte(table), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rates, err := LoadRates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates.Updated != "2025-01-01" {
+		t.Errorf("Updated = %q", rates.Updated)
+	}
+
+	s, _ := Parse("Rp 17.500.000 per bulan")
+	if !rates.Normalize(&s, "usd") {
+		t.Fatal("Normalize failed for a currency in the table")
+	}
+	if s.Normalized.Currency != "USD" || math.Abs(s.Normalized.Min-15000) > 0.01 {
+		t.Errorf("Normalized = %+v, want 15000 USD a year", s.Normalized)
+	}
+
+	s, _ = Parse("£60,000 per year")
+	if rates.Normalize(&s, "EUR") || s.Normalized != nil {
+		t.Errorf("Normalize of a currency missing from the table = %+v", s.Normalized)
+	}
+}
@@ -0,0 +1,214 @@
+package selectors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Tier is the part of a cascade a selector belongs to
+type Tier string
+
+const (
+	TierPrimary   Tier = "primary"
+	TierSecondary Tier = "secondary"
+	TierFallback  Tier = "fallback"
+)
+
+// Tier returns the tier of the selector at index i of All()
+func (c Cascade) Tier(i int) Tier {
+	switch {
+	case i < len(c.Primary):
+		return TierPrimary
+	case i < len(c.Primary)+len(c.Secondary):
+		return TierSecondary
+	}
+	return TierFallback
+}
+
+// Health records which selector of each cascade matched, page by page, so a LinkedIn
+// markup change shows up as selector drift instead of quietly worse results
+type Health struct {
+	mu    sync.Mutex
+	set   *Set
+	pages []*PageHealth
+}
+
+// PageHealth holds the selector matches of one fetched page
+type PageHealth struct {
+	URL     string                 `json:"url"`
+	Winners map[string]map[int]int `json:"winners"`          // cascade -> winning selector index -> lookups it won
+	Misses  map[string]int         `json:"misses,omitempty"` // cascade -> lookups where no selector matched
+
+	// Jobs read from embedded structured data, which spares the page the selector lookups
+	StructuredData int `json:"structured_data,omitempty"`
+
+	health *Health
+}
+
+// NewHealth starts recording selector matches for a selector set
+func NewHealth(set *Set) *Health {
+	return &Health{set: set}
+}
+
+// Page starts recording the matches of one fetched page
+func (h *Health) Page(url string) *PageHealth {
+	page := &PageHealth{URL: url, Winners: make(map[string]map[int]int), Misses: make(map[string]int), health: h}
+	h.mu.Lock()
+	h.pages = append(h.pages, page)
+	h.mu.Unlock()
+	return page
+}
+
+// Record notes that the selector at index won a lookup in the cascade, or that nothing
+// matched when index is negative. A nil page records nothing.
+func (p *PageHealth) Record(cascade string, index int) {
+	if p == nil {
+		return
+	}
+	p.health.mu.Lock()
+	defer p.health.mu.Unlock()
+
+	if index < 0 {
+		p.Misses[cascade]++
+		return
+	}
+	if p.Winners[cascade] == nil {
+		p.Winners[cascade] = make(map[int]int)
+	}
+	p.Winners[cascade][index]++
+}
+
+// RecordStructured notes that n jobs of the page were read from embedded structured
+// data instead of with selectors. A nil page records nothing.
+func (p *PageHealth) RecordStructured(n int) {
+	if p == nil {
+		return
+	}
+	p.health.mu.Lock()
+	defer p.health.mu.Unlock()
+	p.StructuredData += n
+}
+
+// Winner is one selector that won lookups in a cascade
+type Winner struct {
+	Index    int    `json:"index"`
+	Selector string `json:"selector"`
+	Tier     Tier   `json:"tier"`
+	Wins     int    `json:"wins"`
+}
+
+// CascadeReport sums up one cascade over the whole run
+type CascadeReport struct {
+	Name    string       `json:"name"`
+	Pages   int          `json:"pages"`
+	Lookups int          `json:"lookups"`
+	Misses  int          `json:"misses"`
+	Tiers   map[Tier]int `json:"tiers"`   // wins per tier
+	Winners []Winner     `json:"winners"` // most wins first
+}
+
+// Report is the selector health of a run
+type Report struct {
+	Source          string          `json:"source"`
+	StructuredPages int             `json:"structured_data_pages,omitempty"` // pages read from structured data instead of selectors
+	Cascades        []CascadeReport `json:"cascades"`
+	Warnings        []string        `json:"warnings,omitempty"`
+	Pages           []*PageHealth   `json:"pages"`
+}
+
+// Report sums up the recorded pages and flags selector drift
+func (h *Health) Report() Report {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	report := Report{Source: h.set.Source(), Pages: h.pages}
+	byName := make(map[string]*CascadeReport)
+	wins := make(map[string]map[int]int)
+	for _, page := range h.pages {
+		if page.StructuredData > 0 {
+			report.StructuredPages++
+		}
+		touched := make(map[string]bool)
+		for name, indexes := range page.Winners {
+			report := cascadeReport(byName, name)
+			if wins[name] == nil {
+				wins[name] = make(map[int]int)
+			}
+			for index, count := range indexes {
+				wins[name][index] += count
+				report.Lookups += count
+			}
+			touched[name] = true
+		}
+		for name, count := range page.Misses {
+			report := cascadeReport(byName, name)
+			report.Lookups += count
+			report.Misses += count
+			touched[name] = true
+		}
+		for name := range touched {
+			byName[name].Pages++
+		}
+	}
+
+	cascades := h.set.Cascades()
+	for name, summary := range byName {
+		cascade := cascades[name]
+		all := cascade.All()
+		for index, count := range wins[name] {
+			tier := cascade.Tier(index)
+			summary.Tiers[tier] += count
+			selector := ""
+			if index < len(all) {
+				selector = all[index]
+			}
+			summary.Winners = append(summary.Winners, Winner{Index: index, Selector: selector, Tier: tier, Wins: count})
+		}
+		sort.Slice(summary.Winners, func(i, j int) bool {
+			if summary.Winners[i].Wins != summary.Winners[j].Wins {
+				return summary.Winners[i].Wins > summary.Winners[j].Wins
+			}
+			return summary.Winners[i].Index < summary.Winners[j].Index
+		})
+		report.Cascades = append(report.Cascades, *summary)
+	}
+	sort.Slice(report.Cascades, func(i, j int) bool { return report.Cascades[i].Name < report.Cascades[j].Name })
+
+	for _, summary := range report.Cascades {
+		report.Warnings = append(report.Warnings, drift(summary)...)
+	}
+	return report
+}
+
+// cascadeReport returns the report for name, creating it on first use
+func cascadeReport(byName map[string]*CascadeReport, name string) *CascadeReport {
+	if byName[name] == nil {
+		byName[name] = &CascadeReport{Name: name, Tiers: make(map[Tier]int)}
+	}
+	return byName[name]
+}
+
+// drift explains what looks wrong with a cascade: nothing matching, no primary selector
+// matching, generic fallbacks doing most of the work, or most lookups finding nothing
+func drift(c CascadeReport) []string {
+	won := c.Lookups - c.Misses
+	if c.Lookups == 0 {
+		return nil
+	}
+	if won == 0 {
+		return []string{fmt.Sprintf("%s: no selector matched in %d lookups on %d pages - LinkedIn's markup has probably changed", c.Name, c.Lookups, c.Pages)}
+	}
+
+	var warnings []string
+	if c.Tiers[TierPrimary] == 0 {
+		warnings = append(warnings, fmt.Sprintf("%s: no primary selector matched; %q carried %d of %d lookups", c.Name, c.Winners[0].Selector, c.Winners[0].Wins, won))
+	}
+	if fallback := c.Tiers[TierFallback]; fallback*2 > won {
+		warnings = append(warnings, fmt.Sprintf("%s: generic fallback selectors carried %d of %d lookups - results may include junk", c.Name, fallback, won))
+	}
+	if c.Misses*2 > c.Lookups {
+		warnings = append(warnings, fmt.Sprintf("%s: nothing matched in %d of %d lookups", c.Name, c.Misses, c.Lookups))
+	}
+	return warnings
+}
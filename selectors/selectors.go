@@ -0,0 +1,163 @@
+package selectors
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// Version is the selector file format this build understands
+const Version = 1
+
+//go:embed default.json
+var defaultFile []byte
+
+// Cascade is a list of CSS selectors tried in order: primary ones for LinkedIn's current
+// markup, secondary ones for older or alternative layouts, then generic fallbacks
+type Cascade struct {
+	Primary   []string `json:"primary"`
+	Secondary []string `json:"secondary,omitempty"`
+	Fallback  []string `json:"fallback,omitempty"`
+}
+
+// All returns every selector of the cascade in the order they are tried
+func (c Cascade) All() []string {
+	all := make([]string, 0, len(c.Primary)+len(c.Secondary)+len(c.Fallback))
+	all = append(all, c.Primary...)
+	all = append(all, c.Secondary...)
+	return append(all, c.Fallback...)
+}
+
+// Set holds every selector cascade the scraper uses
+type Set struct {
+	Version int    `json:"version"`
+	Updated string `json:"updated,omitempty"` // when the selectors were last checked against LinkedIn
+
+	// Search results page: job cards, and the fields read from each card
+	Search struct {
+		TotalResults Cascade `json:"total_results"`
+		JobCards     Cascade `json:"job_cards"`
+		Title        Cascade `json:"title"`
+		Company      Cascade `json:"company"`
+		Location     Cascade `json:"location"`
+		Posted       Cascade `json:"posted"`
+		Salary       Cascade `json:"salary"`
+	} `json:"search"`
+
+	// Posting page, read with --details
+	Posting struct {
+		Description Cascade `json:"description"`
+		Criteria    Cascade `json:"criteria"`
+		Posted      Cascade `json:"posted"`
+		Salary      Cascade `json:"salary"`
+		Applicants  Cascade `json:"applicants"`
+	} `json:"posting"`
+
+	// Company people and about pages
+	Company struct {
+		EmployeeNames    Cascade `json:"employee_names"`
+		EmployeePosition Cascade `json:"employee_position"`
+	} `json:"company"`
+
+	source string
+}
+
+// Default returns the selectors built into the binary
+func Default() (*Set, error) {
+	set := &Set{source: "built-in defaults"}
+	if err := decode(defaultFile, set); err != nil {
+		return nil, fmt.Errorf("invalid built-in selectors: %v", err)
+	}
+	return set, set.Validate()
+}
+
+// Load returns the built-in selectors with the cascades in filename laid over them. A file
+// only needs the cascades (or tiers of a cascade) it changes; the rest keep their defaults.
+func Load(filename string) (*Set, error) {
+	set, err := Default()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selector file: %v", err)
+	}
+
+	set.Version = 0
+	if err := decode(data, set); err != nil {
+		return nil, fmt.Errorf("invalid selector file %s: %v", filename, err)
+	}
+	if set.Version != Version {
+		return nil, fmt.Errorf("selector file %s has version %d, this build reads version %d", filename, set.Version, Version)
+	}
+	set.source = filename
+
+	return set, set.Validate()
+}
+
+// decode reads a selector file strictly, so a misspelled field is an error rather than ignored
+func decode(data []byte, set *Set) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(set)
+}
+
+// Source names where the selectors were loaded from
+func (s *Set) Source() string {
+	return s.source
+}
+
+// Cascades lists every cascade by its name in the selector file, e.g. "search.title"
+func (s *Set) Cascades() map[string]*Cascade {
+	return map[string]*Cascade{
+		"search.total_results":      &s.Search.TotalResults,
+		"search.job_cards":          &s.Search.JobCards,
+		"search.title":              &s.Search.Title,
+		"search.company":            &s.Search.Company,
+		"search.location":           &s.Search.Location,
+		"search.posted":             &s.Search.Posted,
+		"search.salary":             &s.Search.Salary,
+		"posting.description":       &s.Posting.Description,
+		"posting.criteria":          &s.Posting.Criteria,
+		"posting.posted":            &s.Posting.Posted,
+		"posting.salary":            &s.Posting.Salary,
+		"posting.applicants":        &s.Posting.Applicants,
+		"company.employee_names":    &s.Company.EmployeeNames,
+		"company.employee_position": &s.Company.EmployeePosition,
+	}
+}
+
+// Validate checks that every cascade has selectors and that each one is valid CSS,
+// reporting all problems at once
+func (s *Set) Validate() error {
+	var problems []string
+	for name, cascade := range s.Cascades() {
+		if len(cascade.All()) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: no selectors", name))
+		}
+		for tier, list := range map[string][]string{"primary": cascade.Primary, "secondary": cascade.Secondary, "fallback": cascade.Fallback} {
+			for i, selector := range list {
+				if strings.TrimSpace(selector) == "" {
+					problems = append(problems, fmt.Sprintf("%s.%s[%d]: empty selector", name, tier, i))
+					continue
+				}
+				if _, err := cascadia.Compile(selector); err != nil {
+					problems = append(problems, fmt.Sprintf("%s.%s[%d] %q: %v", name, tier, i, selector, err))
+				}
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	return fmt.Errorf("invalid selectors in %s:\n  %s", s.source, strings.Join(problems, "\n  "))
+}
@@ -0,0 +1,102 @@
+package selectors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	set, err := Default()
+	if err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	if set.Version != Version || set.Source() != "built-in defaults" {
+		t.Errorf("Default() = version %d from %q", set.Version, set.Source())
+	}
+	for name, cascade := range set.Cascades() {
+		if len(cascade.Primary) == 0 {
+			t.Errorf("built-in %s has no primary selectors", name)
+		}
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "selectors.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadOverlaysDefaults(t *testing.T) {
+	defaults, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := writeFile(t, `{"version": 1, "search": {"title": {"primary": [".job-title-v2"]}}}`)
+	set, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got := set.Search.Title.Primary; len(got) != 1 || got[0] != ".job-title-v2" {
+		t.Errorf("search.title.primary = %v, want the file's selector", got)
+	}
+	if got, want := strings.Join(set.Search.Title.Secondary, ","), strings.Join(defaults.Search.Title.Secondary, ","); got != want {
+		t.Errorf("search.title.secondary = %s, want the built-in tier kept", got)
+	}
+	if got, want := strings.Join(set.Search.Company.All(), ","), strings.Join(defaults.Search.Company.All(), ","); got != want {
+		t.Errorf("search.company = %s, want the built-in cascade kept", got)
+	}
+	if set.Source() != path {
+		t.Errorf("Source() = %q, want %q", set.Source(), path)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string // part of the error
+	}{
+		{"missing version", `{"search": {"title": {"primary": [".title"]}}}`, "version 0"},
+		{"newer version", `{"version": 2}`, "version 2"},
+		{"misspelled field", `{"version": 1, "search": {"titel": {"primary": [".title"]}}}`, "titel"},
+		{"invalid CSS", `{"version": 1, "search": {"title": {"primary": ["h3[class="]}}}`, "search.title.primary[0]"},
+		{"empty selector", `{"version": 1, "posting": {"criteria": {"primary": [" "]}}}`, "posting.criteria.primary[0]: empty selector"},
+		{"empty cascade", `{"version": 1, "company": {"employee_names": {"primary": [], "secondary": [], "fallback": []}}}`, "company.employee_names: no selectors"},
+		{"not JSON", `version: 1`, "invalid selector file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load(%s) error = %v, want one mentioning %q", tt.content, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	set, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	set.Search.Title = Cascade{Primary: []string{"h3[", ""}}
+	set.Posting.Applicants = Cascade{}
+
+	err = set.Validate()
+	if err == nil {
+		t.Fatal("Validate accepted broken selectors")
+	}
+	for _, want := range []string{"search.title.primary[0]", "search.title.primary[1]: empty selector", "posting.applicants: no selectors"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, missing %q", err, want)
+		}
+	}
+}
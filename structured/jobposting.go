@@ -0,0 +1,349 @@
+package structured
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goesbams/linkedin-job-scraper/salary"
+)
+
+// Sources of a posting
+const (
+	JSONLD    = "json-ld"   // a schema.org JobPosting in a <script type="application/ld+json">
+	Hydration = "hydration" // a job posting in the JSON a page hydrates from
+)
+
+// Posting is a job posting as a page describes it in embedded structured data
+type Posting struct {
+	Source         string
+	Identifier     string // identifier.value or a LinkedIn URN such as urn:li:fs_normalized_jobPosting:123
+	URL            string
+	Title          string
+	Company        string
+	CompanyURL     string         // hiringOrganization.sameAs or url
+	Location       string         // e.g. "Berlin, Berlin, DE"
+	Remote         bool           // jobLocationType TELECOMMUTE or workRemoteAllowed
+	DatePosted     string         // as given, e.g. "2025-01-10T08:00:00Z" or "2025-01-10"
+	EmploymentType []string       // as LinkedIn labels them, e.g. "Full-time"
+	Industry       string         // industry
+	Function       string         // occupationalCategory
+	Description    string         // HTML, or plain text in hydration data
+	Salary         *salary.Salary // from baseSalary
+}
+
+// employmentLabels maps schema.org employment types to the labels LinkedIn shows
+var employmentLabels = map[string]string{
+	"FULL_TIME": "Full-time", "PART_TIME": "Part-time", "CONTRACTOR": "Contract", "TEMPORARY": "Temporary",
+	"INTERN": "Internship", "VOLUNTEER": "Volunteer", "PER_DIEM": "Per diem", "OTHER": "Other",
+}
+
+// unitPeriods maps QuantitativeValue unitText values to pay periods
+var unitPeriods = map[string]salary.Period{
+	"HOUR": salary.Hourly, "DAY": salary.Daily, "WEEK": salary.Weekly, "MONTH": salary.Monthly, "YEAR": salary.Yearly,
+}
+
+// Find returns the job postings a page embeds: schema.org JobPosting objects in JSON-LD
+// scripts, or else the job postings in the JSON the page hydrates from
+// (<script type="application/json"> and LinkedIn's <code> blocks)
+func Find(doc *goquery.Document) []Posting {
+	var postings []Posting
+	doc.Find("script[type='application/ld+json']").Each(func(_ int, sel *goquery.Selection) {
+		postings = append(postings, fromJSON(sel.Text(), JSONLD)...)
+	})
+	if len(postings) > 0 {
+		return postings
+	}
+
+	doc.Find("script[type='application/json'], code").Each(func(_ int, sel *goquery.Selection) {
+		postings = append(postings, fromJSON(embeddedJSON(sel), Hydration)...)
+	})
+	return postings
+}
+
+// embeddedJSON returns the JSON held by a script or <code> element. LinkedIn wraps the
+// JSON in <code> blocks in an HTML comment, which is not part of the element's text
+// and comes back escaped from Html().
+func embeddedJSON(sel *goquery.Selection) string {
+	if goquery.NodeName(sel) == "script" {
+		return sel.Text()
+	}
+	inner, _ := sel.Html()
+	inner = strings.TrimSpace(inner)
+	if strings.HasPrefix(inner, "<!--") {
+		return html.UnescapeString(strings.TrimSuffix(strings.TrimPrefix(inner, "<!--"), "-->"))
+	}
+	return sel.Text()
+}
+
+// fromJSON finds the job postings anywhere in a JSON document: at the top level, in
+// arrays, in a @graph or in an ItemList
+func fromJSON(text, source string) []Posting {
+	text = strings.TrimSpace(text)
+	if text == "" || (text[0] != '{' && text[0] != '[') {
+		return nil
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(text), &data); err != nil {
+		return nil
+	}
+
+	var postings []Posting
+	walk(data, func(obj map[string]interface{}) bool {
+		var p Posting
+		switch {
+		case hasType(obj["@type"], "JobPosting"):
+			p = schemaPosting(obj, source)
+		case strings.HasSuffix(str(obj["$type"]), ".JobPosting"):
+			p = voyagerPosting(obj)
+		default:
+			return false
+		}
+		if p.Title != "" {
+			postings = append(postings, p)
+		}
+		return true
+	})
+	return postings
+}
+
+// walk calls visit for every object in data, in key order, without descending into
+// objects visit accepts
+func walk(data interface{}, visit func(map[string]interface{}) bool) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if visit(v) {
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			walk(v[key], visit)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walk(item, visit)
+		}
+	}
+}
+
+// schemaPosting reads a schema.org JobPosting
+func schemaPosting(obj map[string]interface{}, source string) Posting {
+	p := Posting{
+		Source:      source,
+		Identifier:  text(obj["identifier"]),
+		URL:         str(obj["url"]),
+		Title:       text(obj["title"]),
+		DatePosted:  str(obj["datePosted"]),
+		Industry:    text(obj["industry"]),
+		Function:    text(obj["occupationalCategory"]),
+		Description: description(str(obj["description"])),
+		Remote:      strings.EqualFold(str(obj["jobLocationType"]), "TELECOMMUTE"),
+	}
+	if id, ok := obj["identifier"].(map[string]interface{}); ok {
+		p.Identifier = text(id["value"])
+	}
+
+	switch org := obj["hiringOrganization"].(type) {
+	case string:
+		p.Company = html.UnescapeString(strings.TrimSpace(org))
+	case map[string]interface{}:
+		p.Company = text(org["name"])
+		for _, key := range []string{"sameAs", "url"} {
+			if links := list(org[key]); len(links) > 0 {
+				p.CompanyURL = links[0]
+				break
+			}
+		}
+	}
+
+	places := obj["jobLocation"]
+	if place, ok := places.(map[string]interface{}); ok {
+		places = []interface{}{place}
+	}
+	if all, ok := places.([]interface{}); ok && len(all) > 0 {
+		if place, ok := all[0].(map[string]interface{}); ok {
+			p.Location = address(place["address"])
+		}
+	}
+
+	for _, value := range list(obj["employmentType"]) {
+		for _, kind := range strings.Split(value, ",") {
+			kind = strings.TrimSpace(kind)
+			if label, ok := employmentLabels[strings.ToUpper(kind)]; ok {
+				kind = label
+			}
+			if kind != "" {
+				p.EmploymentType = append(p.EmploymentType, kind)
+			}
+		}
+	}
+
+	p.Salary = baseSalary(obj["baseSalary"])
+	return p
+}
+
+// voyagerPosting reads a job posting from LinkedIn's own hydration data, whose $type
+// ends in .JobPosting (e.g. com.linkedin.voyager.dash.jobs.JobPosting)
+func voyagerPosting(obj map[string]interface{}) Posting {
+	p := Posting{
+		Source:      Hydration,
+		Identifier:  str(obj["entityUrn"]),
+		URL:         str(obj["jobPostingUrl"]),
+		Title:       text(obj["title"]),
+		Company:     text(obj["companyName"]),
+		Location:    text(obj["formattedLocation"]),
+		Description: description(text(obj["description"])),
+	}
+	if remote, ok := obj["workRemoteAllowed"].(bool); ok {
+		p.Remote = remote
+	}
+	if listed := number(obj["listedAt"]); listed > 0 {
+		p.DatePosted = time.UnixMilli(int64(listed)).UTC().Format(time.RFC3339)
+	}
+	if kind := text(obj["formattedEmploymentStatus"]); kind != "" {
+		p.EmploymentType = []string{kind}
+	}
+	if p.Company == "" {
+		walk(obj["companyDetails"], func(company map[string]interface{}) bool {
+			if p.Company == "" {
+				p.Company = text(company["name"])
+			}
+			return p.Company != ""
+		})
+	}
+	return p
+}
+
+// address formats a PostalAddress as "locality, region, country"
+func address(value interface{}) string {
+	addr, ok := value.(map[string]interface{})
+	if !ok {
+		return text(value)
+	}
+	var parts []string
+	for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+		if part := text(addr[key]); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// baseSalary reads a MonetaryAmount whose value is a number or a QuantitativeValue
+func baseSalary(value interface{}) *salary.Salary {
+	amount, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var min, max float64
+	unit := str(amount["unitText"])
+	switch v := amount["value"].(type) {
+	case map[string]interface{}:
+		min, max = number(v["minValue"]), number(v["maxValue"])
+		if min == 0 && max == 0 {
+			min = number(v["value"])
+		}
+		if u := str(v["unitText"]); u != "" {
+			unit = u
+		}
+	default:
+		min = number(v)
+	}
+
+	pay, ok := salary.New(min, max, str(amount["currency"]), unitPeriods[strings.ToUpper(unit)])
+	if !ok {
+		return nil
+	}
+	return &pay
+}
+
+// description undoes the double escaping some pages apply to HTML descriptions
+func description(value string) string {
+	if !strings.Contains(value, "<") && strings.Contains(value, "&lt;") {
+		return html.UnescapeString(value)
+	}
+	return value
+}
+
+// hasType reports whether a @type value (a string or a list) names typeName
+func hasType(value interface{}, typeName string) bool {
+	for _, t := range list(value) {
+		if t == typeName || strings.HasSuffix(t, "/"+typeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// str returns a string value, or "" for anything else
+func str(value interface{}) string {
+	s, _ := value.(string)
+	return strings.TrimSpace(s)
+}
+
+// text returns the text of a value: a string, a number, the name or text of an object,
+// or the texts of a list joined with commas
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return html.UnescapeString(strings.TrimSpace(v))
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		for _, key := range []string{"name", "text", "value"} {
+			if t := text(v[key]); t != "" {
+				return t
+			}
+		}
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if t := text(item); t != "" {
+				parts = append(parts, t)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+// list returns a string or a list of strings as a list
+func list(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v = strings.TrimSpace(v); v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if s := str(item); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// number reads a JSON number or a numeric string such as "60000" or "60,000.00"
+func number(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		n, _ := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", ""), 64)
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,143 @@
+package structured
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/goesbams/linkedin-job-scraper/salary"
+)
+
+func find(t *testing.T, page string) []Posting {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Find(doc)
+}
+
+func TestFindJSONLD(t *testing.T) {
+	page := `<html><head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Organization", "name": "LinkedIn"}</script>
+<script type="application/ld+json">
+{
+  "@context": "https://schema.org",
+  "@type": "JobPosting",
+  "identifier": {"@type": "PropertyValue", "name": "Nusantara Tech GmbH", "value": "3812345678"},
+  "url": "https://www.linkedin.com/jobs/view/3812345678/",
+  "title": "Senior Go Developer",
+  "datePosted": "2025-01-10T08:00:00Z",
+  "employmentType": ["FULL_TIME", "CONTRACTOR"],
+  "industry": "Software Development",
+  "jobLocationType": "TELECOMMUTE",
+  "hiringOrganization": {"@type": "Organization", "name": "Nusantara Tech GmbH", "sameAs": "https://www.linkedin.com/company/nusantara-tech"},
+  "jobLocation": [{"@type": "Place", "address": {"addressLocality": "Berlin", "addressRegion": "Berlin", "addressCountry": "DE"}}],
+  "baseSalary": {"@type": "MonetaryAmount", "currency": "EUR", "value": {"@type": "QuantitativeValue", "minValue": 65000, "maxValue": "80,000", "unitText": "YEAR"}},
+  "description": "&lt;p&gt;We build payment APIs in Go &amp;amp; Rust.&lt;/p&gt;"
+}
+</script></head><body></body></html>`
+
+	postings := find(t, page)
+	if len(postings) != 1 {
+		t.Fatalf("Find returned %d postings, want 1: %+v", len(postings), postings)
+	}
+	p := postings[0]
+
+	tests := []struct {
+		field string
+		got   string
+		want  string
+	}{
+		{"Source", p.Source, JSONLD},
+		{"Identifier", p.Identifier, "3812345678"},
+		{"URL", p.URL, "https://www.linkedin.com/jobs/view/3812345678/"},
+		{"Title", p.Title, "Senior Go Developer"},
+		{"Company", p.Company, "Nusantara Tech GmbH"},
+		{"CompanyURL", p.CompanyURL, "https://www.linkedin.com/company/nusantara-tech"},
+		{"Location", p.Location, "Berlin, Berlin, DE"},
+		{"DatePosted", p.DatePosted, "2025-01-10T08:00:00Z"},
+		{"EmploymentType", strings.Join(p.EmploymentType, ","), "Full-time,Contract"},
+		{"Industry", p.Industry, "Software Development"},
+		{"Description", p.Description, "<p>We build payment APIs in Go &amp; Rust.</p>"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+	if !p.Remote {
+		t.Error("Remote = false for jobLocationType TELECOMMUTE")
+	}
+	if p.Salary == nil || p.Salary.Min != 65000 || p.Salary.Max != 80000 || p.Salary.Currency != "EUR" || p.Salary.Period != salary.Yearly {
+		t.Errorf("Salary = %+v, want €65,000 – €80,000 a year", p.Salary)
+	}
+}
+
+func TestFindSchemaShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		titles string // titles found, comma-separated
+	}{
+		{"graph", `{"@graph": [{"@type": "WebPage"}, {"@type": "JobPosting", "title": "Go Engineer"}]}`, "Go Engineer"},
+		{"item list", `{"@type": "ItemList", "itemListElement": [{"item": {"@type": "JobPosting", "title": "A"}}, {"item": {"@type": "JobPosting", "title": "B"}}]}`, "A,B"},
+		{"type list", `{"@type": ["Thing", "JobPosting"], "title": "Go Engineer"}`, "Go Engineer"},
+		{"type URL", `{"@type": "http://schema.org/JobPosting", "title": "Go Engineer"}`, "Go Engineer"},
+		{"top-level array", `[{"@type": "JobPosting", "title": "A"}, {"@type": "JobPosting"}]`, "A"},
+		{"no posting", `{"@type": "Organization", "name": "LinkedIn"}`, ""},
+		{"broken JSON", `{"@type": "JobPosting", "title": `, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postings := find(t, `<script type="application/ld+json">`+tt.script+`</script>`)
+			var titles []string
+			for _, p := range postings {
+				titles = append(titles, p.Title)
+			}
+			if got := strings.Join(titles, ","); got != tt.titles {
+				t.Errorf("titles = %q, want %q", got, tt.titles)
+			}
+		})
+	}
+}
+
+func TestFindHydration(t *testing.T) {
+	page := `<html><body>
+<code id="bpr-guid-1"><!--{"data": {"$type": "com.linkedin.voyager.dash.jobs.JobPosting", "entityUrn": "urn:li:fs_normalized_jobPosting:3812349999",
+"title": "Backend Engineer (Go)", "formattedLocation": "Munich, Bavaria, Germany", "workRemoteAllowed": false,
+"listedAt": 1736496000000, "formattedEmploymentStatus": "Full-time",
+"description": {"text": "Berlin Cloud AG &amp; friends"},
+"companyDetails": {"company": {"name": "Berlin Cloud AG"}}}}--></code>
+</body></html>`
+
+	postings := find(t, page)
+	if len(postings) != 1 {
+		t.Fatalf("Find returned %d postings, want 1: %+v", len(postings), postings)
+	}
+	p := postings[0]
+	if p.Source != Hydration || p.Identifier != "urn:li:fs_normalized_jobPosting:3812349999" || p.Title != "Backend Engineer (Go)" {
+		t.Errorf("posting = %+v", p)
+	}
+	if p.Company != "Berlin Cloud AG" || p.Location != "Munich, Bavaria, Germany" {
+		t.Errorf("company and location = %q, %q", p.Company, p.Location)
+	}
+	if p.DatePosted != "2025-01-10T08:00:00Z" {
+		t.Errorf("DatePosted = %q, want listedAt as RFC 3339", p.DatePosted)
+	}
+	if p.Description != "Berlin Cloud AG & friends" {
+		t.Errorf("Description = %q", p.Description)
+	}
+}
+
+func TestFindPrefersJSONLD(t *testing.T) {
+	page := `<script type="application/ld+json">{"@type": "JobPosting", "title": "From JSON-LD"}</script>
+<script type="application/json">{"$type": "com.linkedin.voyager.dash.jobs.JobPosting", "title": "From hydration"}</script>`
+
+	postings := find(t, page)
+	if len(postings) != 1 || postings[0].Title != "From JSON-LD" {
+		t.Errorf("Find = %+v, want only the JSON-LD posting", postings)
+	}
+}